@@ -0,0 +1,92 @@
+// Package: fileLogger
+// File: aggregate.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: merge the logs other processes write to named pipes into one fileLogger
+// DATE: 26-10-14 11:20
+package fileLogger
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"sync"
+)
+
+// AggregateFromPipes reads the lines of each pipe, e.g. the named pipes of a PHP-FPM pool, and writes them
+// to fl at INFO with WriteString. Lines are read whole, the lines of two pipes never interleave.
+// It returns once every pipe is closed by its writers, with the first error met, or when ctx is done.
+// Opening a named pipe blocks until a writer opens it, a pipe still waiting when ctx is done
+// is closed as soon as its writer shows up
+func AggregateFromPipes(ctx context.Context, fl *FileLogger, pipes []string) error {
+	var mu sync.Mutex
+	opened := make(map[*os.File]struct{})
+	done := false
+
+	// close the pipes being read, and those opened later, to stop their goroutines
+	stop := func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		done = true
+		for pipe := range opened {
+			pipe.Close()
+		}
+	}
+
+	errs := make(chan error, len(pipes))
+	for _, path := range pipes {
+		go func(path string) {
+			pipe, err := os.Open(path)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer pipe.Close()
+
+			mu.Lock()
+			if done {
+				mu.Unlock()
+				errs <- ctx.Err()
+				return
+			}
+			opened[pipe] = struct{}{}
+			mu.Unlock()
+
+			errs <- aggregatePipe(fl, pipe)
+		}(path)
+	}
+
+	var first error
+	for range pipes {
+		select {
+		case err := <-errs:
+			if err != nil && first == nil {
+				first = err
+			}
+		case <-ctx.Done():
+			stop()
+			return ctx.Err()
+		}
+	}
+
+	return first
+}
+
+// write each line of pipe to fl until pipe is closed
+func aggregatePipe(fl *FileLogger, pipe *os.File) error {
+	scanner := bufio.NewScanner(pipe)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if err := fl.WriteString(INFO, scanner.Text()); err != nil {
+			return err
+		}
+	}
+
+	// closed by AggregateFromPipes once ctx is done
+	if err := scanner.Err(); err != nil && !errors.Is(err, os.ErrClosed) {
+		return err
+	}
+
+	return nil
+}
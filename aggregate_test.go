@@ -0,0 +1,98 @@
+package fileLogger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// return the path of the read end of a new os.Pipe, standing for a named pipe, and its write end
+func newPipe(t *testing.T) (string, *os.File) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := fmt.Sprintf("/dev/fd/%d", r.Fd())
+	if !isExist(path) {
+		t.Skip("no /dev/fd to open the pipe by path")
+	}
+	t.Cleanup(func() { r.Close() })
+
+	return path, w
+}
+
+func TestAggregateFromPipes(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+
+	paths := []string{}
+	for p := 0; p < 2; p++ {
+		path, w := newPipe(t)
+		paths = append(paths, path)
+		go func(p int) {
+			defer w.Close()
+			for n := 0; n < 100; n++ {
+				fmt.Fprintf(w, "pipe %d line %03d\n", p, n)
+			}
+		}(p)
+	}
+
+	if err := AggregateFromPipes(context.Background(), l, paths); err != nil {
+		t.Fatalf("AggregateFromPipes() = %v, want nil once the writers closed the pipes", err)
+	}
+	l.Flush()
+
+	lines := strings.Split(strings.TrimSpace(readLog(t, filepath.Join(dir, "a.log"))), "\n")
+	if len(lines) != 200 {
+		t.Fatalf("a.log holds %d lines, want the 200 written to the pipes", len(lines))
+	}
+	next := [2]int{}
+	for _, line := range lines {
+		var p, n int
+		if _, err := fmt.Sscanf(line[strings.Index(line, "pipe "):], "pipe %d line %d", &p, &n); err != nil || n != next[p] {
+			t.Fatalf("line %q, want line %03d of pipe %d whole and in order", line, next[p], p)
+		}
+		next[p]++
+	}
+}
+
+func TestAggregateFromPipesCanceled(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	path, w := newPipe(t)
+	defer w.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := AggregateFromPipes(ctx, l, []string{path}); err != context.DeadlineExceeded {
+		t.Errorf("AggregateFromPipes() = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// a pipe closed while it is read is the normal end of aggregatePipe, not an error
+func TestAggregatePipeClosed(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		r.Close()
+	}()
+	if err := aggregatePipe(l, r); err != nil {
+		t.Errorf("aggregatePipe() = %v, want nil for a closed pipe", err)
+	}
+}
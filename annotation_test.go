@@ -0,0 +1,71 @@
+package fileLogger_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aiwuTech/fileLogger"
+	"github.com/aiwuTech/fileLogger/testdata/sub"
+)
+
+// the pkg field holds the import path of the package calling the log method
+func TestPackageAnnotationCallers(t *testing.T) {
+	dir := t.TempDir()
+	l := fileLogger.NewPlainLogger(dir, "a.log", "")
+	l.SetPackageAnnotation(true)
+
+	l.I("from test")
+	sub.Log(l, "from sub")
+	l.Close()
+
+	assertPackages(t, filepath.Join(dir, "a.log"), map[string]string{
+		"from test": "github.com/aiwuTech/fileLogger_test",
+		"from sub":  "github.com/aiwuTech/fileLogger/testdata/sub",
+	})
+}
+
+// a program logging from package main, built by the go command
+func TestPackageAnnotationMain(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds testdata/pkgmain")
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	dir := t.TempDir()
+	if out, err := exec.Command(goCmd, "run", "./testdata/pkgmain", dir).CombinedOutput(); err != nil {
+		t.Fatalf("go run ./testdata/pkgmain: %v\n%s", err, out)
+	}
+
+	assertPackages(t, filepath.Join(dir, "a.log"), map[string]string{
+		"from main": "main",
+		"from sub":  "github.com/aiwuTech/fileLogger/testdata/sub",
+	})
+}
+
+// check the line holding each message of want has the pkg field of its package
+func assertPackages(t *testing.T, path string, want map[string]string) {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for msg, pkg := range want {
+		found := false
+		for _, line := range strings.Split(string(content), "\n") {
+			if strings.Contains(line, msg) {
+				found = strings.Contains(line, " pkg="+pkg)
+				break
+			}
+		}
+		if !found {
+			t.Errorf("%v = %q, want %q logged with pkg=%v", path, content, msg, pkg)
+		}
+	}
+}
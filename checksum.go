@@ -0,0 +1,128 @@
+// Package: fileLogger
+// File: checksum.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: checksums of bak files, to find bak files corrupted on disk
+// DATE: 26-10-14 08:00
+package fileLogger
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	CHECKSUM_FILE = "checksums.json"
+)
+
+// return a new hash of algorithm
+func newHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "md5":
+		return md5.New(), nil
+	case "sha1":
+		return sha1.New(), nil
+	case "sha256":
+		return sha256.New(), nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown checksum algorithm %q", ErrInvalidConfig, algorithm)
+}
+
+// checksum of file, formatted as "algorithm:hex"
+func fileChecksum(file, algorithm string) (string, error) {
+	h, err := newHash(algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	src, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	if _, err := io.Copy(h, src); err != nil {
+		return "", err
+	}
+
+	return algorithm + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// load checksums.json of dir, mapping bak file names to their checksum
+func loadChecksums(dir string) (map[string]string, error) {
+	checksums := make(map[string]string)
+
+	b, err := ioutil.ReadFile(joinFilePath(dir, CHECKSUM_FILE))
+	if os.IsNotExist(err) {
+		return checksums, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &checksums); err != nil {
+		return nil, err
+	}
+
+	return checksums, nil
+}
+
+// checksum logFileBak into checksums.json of fileDir, the json file is replaced by rename
+func (f *FileLogger) recordChecksum(logFileBak string) error {
+	sum, err := fileChecksum(logFileBak, f.checksumAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	checksums, err := loadChecksums(f.fileDir)
+	if err != nil {
+		return err
+	}
+	checksums[filepath.Base(logFileBak)] = sum
+
+	b, err := json.MarshalIndent(checksums, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	checksumFile := joinFilePath(f.fileDir, CHECKSUM_FILE)
+	if err := ioutil.WriteFile(checksumFile+".tmp", b, 0666); err != nil {
+		return err
+	}
+
+	return os.Rename(checksumFile+".tmp", checksumFile)
+}
+
+// VerifyAll checksums every bak file recorded in checksums.json again, reporting for each
+// whether it still matches. A recorded bak file which no longer exists does not match
+func (f *FileLogger) VerifyAll() (map[string]bool, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	checksums, err := loadChecksums(f.fileDir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReadFailed, err)
+	}
+
+	result := make(map[string]bool, len(checksums))
+	for name, sum := range checksums {
+		algorithm := sum
+		if i := strings.Index(sum, ":"); i >= 0 {
+			algorithm = sum[:i]
+		}
+
+		current, err := fileChecksum(joinFilePath(f.fileDir, name), algorithm)
+		result[name] = err == nil && current == sum
+	}
+
+	return result, nil
+}
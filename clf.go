@@ -0,0 +1,93 @@
+// Package: fileLogger
+// File: clf.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: web server access logs in the Apache Combined Log Format
+// DATE: 26-10-14 09:50
+package fileLogger
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	CLF_TIMEFORMAT = "02/Jan/2006:15:04:05 -0700"
+)
+
+// CombinedLogEntry holds one request of an access log, empty strings and a zero Bytes are written as "-"
+type CombinedLogEntry struct {
+	RemoteHost string
+	Ident      string
+	User       string
+	Time       time.Time
+	Request    string // request line, e.g. GET /index.html HTTP/1.1
+	Status     int
+	Bytes      int64
+	Referer    string
+	UserAgent  string
+}
+
+// CombinedLogFormatter writes entries in the Combined Log Format of Apache and Nginx:
+// %h %l %u [%t] "%r" %>s %b "%{Referer}i" "%{User-Agent}i".
+// Use it with SetFlags(0) and an empty prefix, and log through WriteHTTPEntry.
+// Entries of the other log methods get "-" for the request fields and their message as %r
+type CombinedLogFormatter struct{}
+
+func (c *CombinedLogFormatter) Format(e *Entry) string {
+	return fmt.Sprintf(`%s %s %s [%s] "%s" %s %s "%s" "%s"`,
+		clfField(e, "remote_host"),
+		clfField(e, "ident"),
+		clfField(e, "user"),
+		e.Time.Format(CLF_TIMEFORMAT),
+		clfQuote(e.Msg),
+		clfField(e, "status"),
+		clfField(e, "bytes"),
+		clfQuote(clfField(e, "referer")),
+		clfQuote(clfField(e, "user_agent")))
+}
+
+func init() {
+	RegisterFormatter("combined", &CombinedLogFormatter{})
+}
+
+// WriteHTTPEntry writes an access log entry, it is not subject to the log level.
+// A zero Time is the time of the call
+func (f *FileLogger) WriteHTTPEntry(c CombinedLogEntry) error {
+	_, file, line, _ := runtime.Caller(1) //calldepth=2
+
+	e := f.entry(OFF, file, line, c.Request, nil)
+	if !c.Time.IsZero() {
+		e.Time = c.Time
+	}
+	e.setField("remote_host", c.RemoteHost)
+	e.setField("ident", c.Ident)
+	e.setField("user", c.User)
+	e.setField("status", c.Status)
+	e.setField("bytes", c.Bytes)
+	e.setField("referer", c.Referer)
+	e.setField("user_agent", c.UserAgent)
+
+	return f.send(e)
+}
+
+// return the field key of e formatted for the log line, "-" when it is missing or empty
+func clfField(e *Entry, key string) string {
+	v, ok := e.Fields[key]
+	if !ok {
+		return "-"
+	}
+
+	str := fmt.Sprint(v)
+	if str == "" || (key == "bytes" && str == "0") {
+		return "-"
+	}
+
+	return str
+}
+
+// escape the quotes of a quoted field
+func clfQuote(str string) string {
+	return strings.Replace(str, `"`, `\"`, -1)
+}
@@ -0,0 +1,64 @@
+package fileLogger
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// the Write* methods racing Close return ErrClosed, the log methods return, neither panics
+func TestWriteWhileClosing(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		l := NewPlainLogger(t.TempDir(), "a.log", "")
+
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for n := 0; n < 200; n++ {
+					if err := l.WriteString(INFO, "entry"); err != nil && !errors.Is(err, ErrClosed) {
+						t.Errorf("WriteString() = %v, want nil or ErrClosed", err)
+						return
+					}
+					l.I("entry")
+				}
+			}()
+		}
+		l.Close()
+		wg.Wait()
+
+		if err := l.WriteString(INFO, "closed"); !errors.Is(err, ErrClosed) {
+			t.Errorf("WriteString() after Close = %v, want ErrClosed", err)
+		}
+	}
+}
+
+// once closed, a full logChan has no reader left: the log methods must not block on it
+func TestLogAfterCloseDoesNotBlock(t *testing.T) {
+	l := NewSizeLogger(t.TempDir(), "a.log", "", 3, 1, MB, DEFAULT_LOG_SCAN, 1)
+	l.Close()
+
+	for n := 0; n < 10; n++ {
+		l.I("entry %d", n)
+	}
+}
+
+// the entries still queued when Close is called are written before the log file is closed
+func TestCloseWritesQueuedEntries(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+
+	for n := 0; n < 1000; n++ {
+		l.I("entry %d", n)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Count(readLog(t, filepath.Join(dir, "a.log")), "entry "); n != 1000 {
+		t.Errorf("a.log holds %d entries, want the 1000 logged before Close", n)
+	}
+}
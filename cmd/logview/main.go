@@ -0,0 +1,278 @@
+// Package: main
+// File: main.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: logview prints the entries of a log file written by fileLogger, filtered and formatted
+// DATE: 26-10-14 10:50
+//
+//	logview --file /usr/local/aiwuTech/log/test.log --level WARN --after 2026-10-14T08:00:00+08:00
+//	logview --file test.log.3.gz --format logfmt --grep timeout
+//	logview --file test.log --tail --json
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/aiwuTech/fileLogger"
+)
+
+const (
+	TIMEFORMAT = "2006/01/02 15:04:05.000000"
+)
+
+var (
+	file    = flag.String("file", "", "log file to read, a .gz file is decompressed")
+	format  = flag.String("format", "text", "output format: text, json or logfmt")
+	level   = flag.String("level", "TRACE", "minimum level: TRACE, INFO, WARN or ERROR, entries of Print() always pass")
+	after   = flag.String("after", "", "only entries at or after this RFC3339 time")
+	before  = flag.String("before", "", "only entries before this RFC3339 time")
+	tail    = flag.Bool("tail", false, "keep following the file as it is written and rotated")
+	grep    = flag.String("grep", "", "only entries whose output matches this regexp")
+	rawJSON = flag.Bool("json", false, "output the matching lines of a JSON log as they are, same as --format json with --tail")
+	stats   = flag.Bool("stats", false, "print the count of matching entries per level when done")
+)
+
+// filter holds the parsed flags deciding which entries are printed
+type filter struct {
+	level  fileLogger.LEVEL
+	after  time.Time
+	before time.Time
+	grep   *regexp.Regexp
+}
+
+// printer writes the entries passing the filter to stdout, it is the sink of --tail
+type printer struct {
+	filter
+	counts map[string]int
+}
+
+func main() {
+	flag.Parse()
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "logview: --file is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	p, err := newPrinter()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "logview: %v\n", err)
+		os.Exit(2)
+	}
+
+	if *tail {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		err = fileLogger.ForwardFile(ctx, *file, p)
+		stop()
+		if err == context.Canceled {
+			err = nil
+		}
+	} else {
+		err = p.readFile(*file)
+	}
+
+	if *stats {
+		p.printStats()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "logview: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func newPrinter() (*printer, error) {
+	p := &printer{counts: make(map[string]int)}
+
+	switch *format {
+	case "text", "json", "logfmt":
+	default:
+		return nil, fmt.Errorf("unknown format %q", *format)
+	}
+
+	levels := map[string]fileLogger.LEVEL{
+		"TRACE": fileLogger.TRACE,
+		"INFO":  fileLogger.INFO,
+		"WARN":  fileLogger.WARN,
+		"ERROR": fileLogger.ERROR,
+	}
+	var ok bool
+	if p.level, ok = levels[strings.ToUpper(*level)]; !ok {
+		return nil, fmt.Errorf("unknown level %q", *level)
+	}
+
+	var err error
+	if *after != "" {
+		if p.after, err = time.Parse(time.RFC3339, *after); err != nil {
+			return nil, err
+		}
+	}
+	if *before != "" {
+		if p.before, err = time.Parse(time.RFC3339, *before); err != nil {
+			return nil, err
+		}
+	}
+	if *grep != "" {
+		if p.grep, err = regexp.Compile(*grep); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// print the entries of path once
+func (p *printer) readFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	var r io.Reader = src
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(src)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		e, err := fileLogger.ParseLine(line)
+		if err != nil {
+			e = &fileLogger.Entry{Level: fileLogger.OFF, Msg: line}
+		}
+		p.print(e, line)
+	}
+
+	return scanner.Err()
+}
+
+// Write prints an entry forwarded by --tail
+func (p *printer) Write(e *fileLogger.Entry) error {
+	p.print(e, "")
+	return nil
+}
+
+// print e when it passes the filter, raw is its line as read or "" when it is not known
+func (p *printer) print(e *fileLogger.Entry, raw string) {
+	if e.Level < p.level {
+		return
+	}
+	if !p.after.IsZero() && e.Time.Before(p.after) {
+		return
+	}
+	if !p.before.IsZero() && (e.Time.IsZero() || !e.Time.Before(p.before)) {
+		return
+	}
+
+	out := ""
+	switch {
+	case *rawJSON && strings.HasPrefix(raw, "{"):
+		out = raw
+	case *rawJSON || *format == "json":
+		out = (&fileLogger.JSONFormatter{}).Format(e)
+	case *format == "logfmt":
+		out = logfmt(e)
+	default:
+		out = text(e)
+	}
+	if p.grep != nil && !p.grep.MatchString(out) {
+		return
+	}
+
+	p.counts[e.Level.String()]++
+	fmt.Println(out)
+}
+
+func (p *printer) printStats() {
+	names := make([]string, 0, len(p.counts))
+	total := 0
+	for name, n := range p.counts {
+		names = append(names, name)
+		total += n
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "%-5v %v\n", name, p.counts[name])
+	}
+	fmt.Fprintf(os.Stderr, "total %v\n", total)
+}
+
+// text writes time [file:line][LEVEL] message key=value..., without the console colors of the log file
+func text(e *fileLogger.Entry) string {
+	str := ""
+	if !e.Time.IsZero() {
+		str += e.Time.Format(TIMEFORMAT) + " "
+	}
+	if e.File != "" {
+		str += fmt.Sprintf("[%v:%v]", e.File, e.Line)
+	}
+	if e.Level < fileLogger.OFF {
+		str += "[" + e.Level.String() + "] "
+	}
+	str += e.Msg
+
+	for _, k := range keys(e) {
+		str += fmt.Sprintf(" %v=%v", k, e.Fields[k])
+	}
+
+	return str
+}
+
+// logfmt writes time=... level=... file=... line=... msg=... key=value...
+func logfmt(e *fileLogger.Entry) string {
+	pairs := []string{}
+	if !e.Time.IsZero() {
+		pairs = append(pairs, "time="+e.Time.Format(time.RFC3339Nano))
+	}
+	if e.Level < fileLogger.OFF {
+		pairs = append(pairs, "level="+e.Level.String())
+	}
+	if e.File != "" {
+		pairs = append(pairs, "file="+e.File, "line="+strconv.Itoa(e.Line))
+	}
+	pairs = append(pairs, "msg="+logfmtValue(e.Msg))
+
+	for _, k := range keys(e) {
+		pairs = append(pairs, k+"="+logfmtValue(fmt.Sprint(e.Fields[k])))
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// quote a logfmt value holding spaces, quotes or an equal sign
+func logfmtValue(v string) string {
+	if v == "" || strings.ContainsAny(v, " \t\"=") {
+		return strconv.Quote(v)
+	}
+
+	return v
+}
+
+func keys(e *fileLogger.Entry) []string {
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
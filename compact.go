@@ -0,0 +1,72 @@
+// Package: fileLogger
+// File: compact.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: rewrite a log file without the entries below a level
+// DATE: 26-10-14 11:40
+package fileLogger
+
+import (
+	"bufio"
+	"compress/gzip"
+	"os"
+	"strings"
+)
+
+// Compact writes the lines of srcPath whose entry is at least minLevel to dstPath and returns the number
+// of lines left out. Entries of Print() are always kept, a line that can not be parsed follows
+// the previous entry. A path ending in .gz is read or written gzip compressed. srcPath is not modified
+func Compact(srcPath, dstPath string, minLevel LEVEL) (removed int, err error) {
+	src, err := openLog(srcPath)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if closeErr := dst.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	var gz *gzip.Writer
+	w := bufio.NewWriter(dst)
+	if strings.HasSuffix(dstPath, ".gz") {
+		gz = gzip.NewWriter(dst)
+		w = bufio.NewWriter(gz)
+	}
+
+	keep := true
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if e, err := ParseLine(line); err == nil {
+			keep = e.Level >= minLevel
+		}
+		if !keep {
+			removed++
+			continue
+		}
+
+		w.WriteString(line)
+		if err := w.WriteByte('\n'); err != nil {
+			return removed, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return removed, err
+	}
+
+	if err := w.Flush(); err != nil {
+		return removed, err
+	}
+	if gz != nil {
+		return removed, gz.Close()
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,99 @@
+package fileLogger
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// write the entries of fn to a log file of dir named name and return its path
+func writeLogFile(t *testing.T, dir, name string, fn func(l *FileLogger)) string {
+	t.Helper()
+
+	l := NewPlainLogger(dir, name, "")
+	fn(l)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return filepath.Join(dir, name)
+}
+
+// content of path, decompressed when it ends in .gz
+func readLogFile(t *testing.T, path string) string {
+	t.Helper()
+
+	src, err := openLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	content, err := io.ReadAll(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func TestCompact(t *testing.T) {
+	dir := t.TempDir()
+	src := writeLogFile(t, dir, "a.log", func(l *FileLogger) {
+		l.T("trace\ncontinued")
+		l.I("info")
+		l.W("warn")
+		l.E("error\ncontinued")
+		l.Print("printed")
+	})
+	before := readLog(t, src)
+
+	for _, dst := range []string{"b.log", "b.log.gz"} {
+		t.Run(dst, func(t *testing.T) {
+			dst := filepath.Join(dir, dst)
+			removed, err := Compact(src, dst, WARN)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if removed != 3 {
+				t.Errorf("%d lines removed, want the 2 of the trace entry and the info line", removed)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(readLogFile(t, dst), "\n"), "\n")
+			want := []string{"[WARN] warn", "[ERROR] error", "continued", "printed"}
+			if len(lines) != len(want) {
+				t.Fatalf("compacted file holds %q, want %d lines", lines, len(want))
+			}
+			for i, line := range lines {
+				if !strings.Contains(line, want[i]) {
+					t.Errorf("line %d is %q, want %q in it", i, line, want[i])
+				}
+			}
+		})
+	}
+
+	if after := readLog(t, src); after != before {
+		t.Errorf("source file modified to %q", after)
+	}
+}
+
+func TestCompactGzipSource(t *testing.T) {
+	dir := t.TempDir()
+	src := writeLogFile(t, dir, "a.log", func(l *FileLogger) {
+		l.I("info")
+		l.E("error")
+	})
+	gz := filepath.Join(dir, "a.log.gz")
+	if _, err := Compact(src, gz, TRACE); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(dir, "b.log")
+	if removed, err := Compact(gz, dst, ERROR); removed != 1 || err != nil {
+		t.Fatalf("Compact removed %d lines, %v, want the info line", removed, err)
+	}
+	if content := readLog(t, dst); strings.Contains(content, "info") || !strings.Contains(content, "[ERROR] error") {
+		t.Errorf("compacted file holds %q, want only the error", content)
+	}
+}
@@ -0,0 +1,117 @@
+// Package: fileLogger
+// File: config.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: build a fileLogger from a config, e.g. decoded from a json config file
+// DATE: 26-10-14 06:40
+package fileLogger
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Config holds the parameters of the fileLogger constructors, zero values fall back to the defaults
+type Config struct {
+	SplitType SplitType `json:"splitType"`
+	FileDir   string    `json:"fileDir"`
+	FileName  string    `json:"fileName"`
+	Prefix    string    `json:"prefix"`
+
+	// SplitType_Size
+	FileCount int   `json:"fileCount"`
+	FileSize  int64 `json:"fileSize"`
+	Unit      UNIT  `json:"unit"`
+
+	// SplitType_HybridScheduled, FileSize is not used
+	PeakStart   time.Duration `json:"peakStart"`
+	PeakEnd     time.Duration `json:"peakEnd"`
+	PeakSize    int64         `json:"peakSize"`
+	OffPeakSize int64         `json:"offPeakSize"`
+
+	LogScan int64 `json:"logScan"`
+	LogSeq  int   `json:"logSeq"`
+
+	// name of a formatter registered by RegisterFormatter, default is "text"
+	FormatterName string `json:"formatterName"`
+
+	// skip checking that FileDir is writable, see SetStartupCheck
+	DisableStartupCheck bool `json:"disableStartupCheck"`
+
+	// write into the subdirectory of FileDir named by the hostname, see SetHostnameSubdir
+	HostnameSubdir bool `json:"hostnameSubdir"`
+}
+
+// NewLoggerFromConfig return a logger built from cfg
+func NewLoggerFromConfig(cfg Config) (*FileLogger, error) {
+	if cfg.FileDir == "" || cfg.FileName == "" {
+		return nil, fmt.Errorf("%w: fileDir and fileName are required", ErrInvalidPath)
+	}
+
+	if cfg.HostnameSubdir {
+		cfg.FileDir = joinFilePath(cfg.FileDir, hostname())
+	}
+
+	if !cfg.DisableStartupCheck {
+		if err := checkWritable(cfg.FileDir, joinFilePath(cfg.FileDir, cfg.FileName)); err != nil {
+			return nil, err
+		}
+	}
+
+	formatter := Formatter(&TextFormatter{})
+	if cfg.FormatterName != "" {
+		var ok bool
+		if formatter, ok = GetFormatter(cfg.FormatterName); !ok {
+			return nil, fmt.Errorf("%w: unknown formatter %q", ErrInvalidConfig, cfg.FormatterName)
+		}
+	}
+
+	cfg = cfg.withDefaults()
+
+	// the formatter and the startup check are set before the log file is opened and logWriter is started
+	var logger *FileLogger
+	switch cfg.SplitType {
+	case SplitType_Size:
+		logger = newSizeLogger(cfg.FileDir, cfg.FileName, cfg.Prefix,
+			cfg.FileCount, cfg.FileSize, cfg.Unit, cfg.LogScan, cfg.LogSeq)
+	case SplitType_Daily:
+		logger = newDailyLogger(cfg.FileDir, cfg.FileName, cfg.Prefix, cfg.LogScan, cfg.LogSeq)
+	case SplitType_HybridScheduled:
+		logger = newHybridScheduledLogger(cfg.FileDir, cfg.FileName, cfg.Prefix,
+			cfg.PeakStart, cfg.PeakEnd, cfg.PeakSize, cfg.OffPeakSize, cfg.Unit)
+	case SplitType_None:
+		logger = newPlainLogger(cfg.FileDir, cfg.FileName, cfg.Prefix)
+	default:
+		return nil, fmt.Errorf("%w: unknown splitType %v", ErrInvalidConfig, cfg.SplitType)
+	}
+
+	logger.formatter = formatter
+	logger.startupCheck = !cfg.DisableStartupCheck
+	logger.hostnameSubdir = cfg.HostnameSubdir
+	if err := logger.initLogger(); err != nil {
+		log.Printf("FileLogger init error: %v", err)
+	}
+
+	return logger, nil
+}
+
+// return cfg with the zero values replaced by the defaults
+func (cfg Config) withDefaults() Config {
+	if cfg.FileCount <= 0 {
+		cfg.FileCount = DEFAULT_FILE_COUNT
+	}
+	if cfg.FileSize <= 0 {
+		cfg.FileSize = DEFAULT_FILE_SIZE
+	}
+	if cfg.Unit <= 0 {
+		cfg.Unit = DEFAULT_FILE_UNIT
+	}
+	if cfg.LogScan <= 0 {
+		cfg.LogScan = DEFAULT_LOG_SCAN
+	}
+	if cfg.LogSeq <= 0 {
+		cfg.LogSeq = DEFAULT_LOG_SEQ
+	}
+
+	return cfg
+}
@@ -0,0 +1,77 @@
+package fileLogger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewLoggerFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	l, err := NewLoggerFromConfig(Config{
+		SplitType:           SplitType_None,
+		FileDir:             dir,
+		FileName:            "a.log",
+		FormatterName:       "json",
+		DisableStartupCheck: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if l.startupCheck {
+		t.Error("startupCheck is on with DisableStartupCheck")
+	}
+
+	l.I("entry")
+	l.Flush()
+	if content := readLog(t, filepath.Join(dir, "a.log")); !strings.Contains(content, `{"file":"config_test.go","level":"INFO"`) {
+		t.Errorf("a.log = %q, want the entry formatted by the json formatter", content)
+	}
+}
+
+func TestHostnameSubdir(t *testing.T) {
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skip(err)
+	}
+
+	dir := t.TempDir()
+	l, err := NewLoggerFromConfig(Config{SplitType: SplitType_None, FileDir: dir, FileName: "a.log", HostnameSubdir: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	l.I("entry")
+	l.Flush()
+	if content := readLog(t, filepath.Join(dir, host, "a.log")); !strings.Contains(content, "entry") {
+		t.Errorf("%v/a.log = %q, want the entry", host, content)
+	}
+	if isExist(filepath.Join(dir, "a.log")) {
+		t.Error("a.log created in the shared directory")
+	}
+}
+
+func TestSetHostnameSubdir(t *testing.T) {
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skip(err)
+	}
+
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.SetHostnameSubdir(true)
+
+	l.I("entry")
+	l.Flush()
+	if content := readLog(t, filepath.Join(dir, host, "a.log")); !strings.Contains(content, "entry") {
+		t.Errorf("%v/a.log = %q, want the entry", host, content)
+	}
+	if isExist(filepath.Join(dir, "a.log")) {
+		t.Error("the empty a.log of the shared directory is left behind")
+	}
+}
@@ -0,0 +1,110 @@
+// Package: fileLogger
+// File: context.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: values bound to a context.Context and written with every log call made with that context
+// DATE: 26-10-14 07:00
+package fileLogger
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// default key of the contextID carried in a context.Context, see SetContextKey
+type contextIDKey struct{}
+
+var lastContextID atomic.Uint64
+
+// the context.Context key carrying the contextID
+func (f *FileLogger) contextKey() interface{} {
+	if key := f.ctxKey.Load(); key != nil {
+		return *key
+	}
+
+	return contextIDKey{}
+}
+
+// NewContext returns a copy of parent carrying a new contextID, values set on it by SetContext
+// are written as fields by the *Context log methods. The values are dropped once parent is done,
+// or by ReleaseContext for a parent that is never done
+func (f *FileLogger) NewContext(parent context.Context) context.Context {
+	id := lastContextID.Add(1)
+	f.contexts.Store(id, new(sync.Map))
+
+	ctx := context.WithValue(parent, f.contextKey(), id)
+	if parent.Done() != nil {
+		context.AfterFunc(ctx, func() {
+			f.contexts.Delete(id)
+		})
+	}
+
+	return ctx
+}
+
+// SetContext binds key & value to the contextID of ctx, it is a no-op for a ctx not made by NewContext
+func (f *FileLogger) SetContext(ctx context.Context, key, value interface{}) {
+	if values, ok := f.contextValues(ctx); ok {
+		values.Store(key, value)
+	}
+}
+
+// ReleaseContext drops the values bound to the contextID of ctx
+func (f *FileLogger) ReleaseContext(ctx context.Context) {
+	if id, ok := ctx.Value(f.contextKey()).(uint64); ok {
+		f.contexts.Delete(id)
+	}
+}
+
+// the values bound to the contextID of ctx
+func (f *FileLogger) contextValues(ctx context.Context) (*sync.Map, bool) {
+	id, ok := ctx.Value(f.contextKey()).(uint64)
+	if !ok {
+		return nil, false
+	}
+
+	values, ok := f.contexts.Load(id)
+	if !ok {
+		return nil, false
+	}
+
+	return values.(*sync.Map), true
+}
+
+// leveled log carrying the values of ctx as fields
+func (f *FileLogger) logContext(ctx context.Context, level LEVEL, format string, v []interface{}) {
+	_, file, line, _ := runtime.Caller(2) //calldepth=3
+	if f.enabled(level) {
+		e := f.entry(level, file, line, fmt.Sprintf(format, v...), v)
+		if values, ok := f.contextValues(ctx); ok {
+			values.Range(func(key, value interface{}) bool {
+				e.setField(fmt.Sprint(key), value)
+				return true
+			})
+		}
+
+		f.queue(e)
+	}
+}
+
+// trace log with the values of ctx
+func (f *FileLogger) TraceContext(ctx context.Context, format string, v ...interface{}) {
+	f.logContext(ctx, TRACE, format, v)
+}
+
+// info log with the values of ctx
+func (f *FileLogger) InfoContext(ctx context.Context, format string, v ...interface{}) {
+	f.logContext(ctx, INFO, format, v)
+}
+
+// warning log with the values of ctx
+func (f *FileLogger) WarnContext(ctx context.Context, format string, v ...interface{}) {
+	f.logContext(ctx, WARN, format, v)
+}
+
+// error log with the values of ctx
+func (f *FileLogger) ErrorContext(ctx context.Context, format string, v ...interface{}) {
+	f.logContext(ctx, ERROR, format, v)
+}
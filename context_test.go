@@ -0,0 +1,78 @@
+package fileLogger
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// the values of two contexts made by the same logger, or by two loggers, do not mix
+func TestContextIsolation(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	a := NewPlainLogger(dirA, "a.log", "")
+	defer a.Close()
+	b := NewPlainLogger(dirB, "b.log", "")
+	defer b.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := a.NewContext(context.Background())
+			a.SetContext(ctx, "request_id", i)
+			for n := 0; n < 100; n++ {
+				a.InfoContext(ctx, "request %d", i)
+			}
+		}(i)
+	}
+	ctxB := b.NewContext(context.Background())
+	b.SetContext(ctxB, "user", "bob")
+	wg.Wait()
+
+	// a context of a carries no value for b and the other way around
+	ctxA := a.NewContext(context.Background())
+	a.SetContext(ctxA, "user", "alice")
+	b.InfoContext(ctxA, "context of a")
+	a.InfoContext(ctxB, "context of b")
+	a.Flush()
+	b.Flush()
+
+	for _, line := range strings.Split(strings.TrimSpace(readLog(t, filepath.Join(dirA, "a.log"))), "\n") {
+		if strings.Contains(line, "user=") {
+			t.Errorf("a.log line %q holds a value of b's context", line)
+		}
+		for i := 0; i < 2; i++ {
+			if strings.Contains(line, fmt.Sprintf("request %d", i)) && !strings.Contains(line, fmt.Sprintf("request_id=%d", i)) {
+				t.Errorf("a.log line %q, want request_id=%d", line, i)
+			}
+		}
+	}
+	if content := readLog(t, filepath.Join(dirB, "b.log")); !strings.Contains(content, "context of a") || strings.Contains(content, "user=") {
+		t.Errorf("b.log = %q, want the entry without a value of a's context", content)
+	}
+}
+
+func TestSetContextKeyWhileLogging(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	type key struct{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for n := 0; n < 100; n++ {
+			l.InfoContext(context.Background(), "entry")
+		}
+	}()
+	l.SetContextKey(key{})
+	<-done
+
+	ctx := l.NewContext(context.Background())
+	if _, ok := ctx.Value(key{}).(uint64); !ok {
+		t.Error("NewContext did not use the key set by SetContextKey")
+	}
+}
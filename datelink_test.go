@@ -0,0 +1,69 @@
+package fileLogger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDateLinks(t *testing.T) {
+	dir := t.TempDir()
+	linkDir := filepath.Join(dir, "links")
+	l := NewSizeLogger(dir, "a.log", "", 1, 1, MB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+	defer l.Close()
+	l.SetDateLinks(true, linkDir)
+
+	// 26 hours apart, the two splits happen on different dates
+	east, west := time.FixedZone("east", 14*3600), time.FixedZone("west", -12*3600)
+	rotate := func(loc *time.Location, msg string) string {
+		l.SetTimezone(loc)
+		l.I("%s", msg)
+		l.Flush()
+		if err := l.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+		return filepath.Join(linkDir, "a.log."+time.Now().In(loc).Format(DATEFORMAT)+".1")
+	}
+
+	bak := filepath.Join(dir, "a.log.1")
+	first := rotate(east, "first")
+	bakInfo, err := os.Stat(bak)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if linkInfo, err := os.Stat(first); err != nil || !os.SameFile(bakInfo, linkInfo) {
+		t.Fatalf("%v is not a hard link of %v: %v", first, bak, err)
+	}
+
+	// the second split overwrites a.log.1
+	second := rotate(west, "second")
+	if content := readLog(t, bak); !strings.Contains(content, "second") || strings.Contains(content, "first") {
+		t.Errorf("%v holds %q, want the second entry only", bak, content)
+	}
+	if content := readLog(t, first); !strings.Contains(content, "first") || strings.Contains(content, "second") {
+		t.Errorf("%v holds %q, want the first entry kept", first, content)
+	}
+	if content := readLog(t, second); !strings.Contains(content, "second") {
+		t.Errorf("%v holds %q, want the second entry", second, content)
+	}
+}
+
+func TestDateLinksDisabled(t *testing.T) {
+	dir := t.TempDir()
+	l := NewSizeLogger(dir, "a.log", "", 1, 1, MB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+	defer l.Close()
+	l.SetDateLinks(true, "")
+	l.SetDateLinks(false, "")
+
+	l.I("entry")
+	l.Flush()
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if links, _ := filepath.Glob(filepath.Join(dir, "a.log.*.1")); len(links) != 0 {
+		t.Errorf("date links %v created once disabled", links)
+	}
+}
@@ -0,0 +1,87 @@
+// Package: fileLogger
+// File: dedup.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: drop the entries written twice across log files, e.g. by retries
+// DATE: 26-10-14 12:00
+package fileLogger
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"io"
+)
+
+// DeduplicateFiles writes the lines of srcPaths, read in order, to dst, leaving out those whose entry repeats
+// an earlier one and returning their count. Entries are the same when their keyFields are: time, level, file,
+// line, msg (or message) and the names of their fields. No keyFields, or a line that can not be parsed,
+// compares the whole line. Seen entries are kept as 64 bits hashes, paths ending in .gz are decompressed
+func DeduplicateFiles(srcPaths []string, dst io.Writer, keyFields []string) (duplicatesRemoved int, err error) {
+	seen := make(map[uint64]struct{})
+	w := bufio.NewWriter(dst)
+
+	for _, path := range srcPaths {
+		src, err := openLog(path)
+		if err != nil {
+			return duplicatesRemoved, err
+		}
+
+		scanner := bufio.NewScanner(src)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			key := dedupKey(line, keyFields)
+			if _, ok := seen[key]; ok {
+				duplicatesRemoved++
+				continue
+			}
+			seen[key] = struct{}{}
+
+			w.WriteString(line)
+			if err := w.WriteByte('\n'); err != nil {
+				src.Close()
+				return duplicatesRemoved, err
+			}
+		}
+		err = scanner.Err()
+		src.Close()
+		if err != nil {
+			return duplicatesRemoved, err
+		}
+	}
+
+	return duplicatesRemoved, w.Flush()
+}
+
+// hash the keyFields of the entry of line
+func dedupKey(line string, keyFields []string) uint64 {
+	h := fnv.New64a()
+
+	e, err := ParseLine(line)
+	if len(keyFields) == 0 || err != nil {
+		h.Write([]byte(line))
+		return h.Sum64()
+	}
+
+	for _, k := range keyFields {
+		var v interface{}
+		switch k {
+		case "time":
+			v = e.Time.UnixNano()
+		case "level":
+			v = e.Level
+		case "file":
+			v = e.File
+		case "line":
+			v = e.Line
+		case "msg", "message":
+			v = e.Msg
+		default:
+			v = e.Fields[k]
+		}
+		// the separator keeps ("ab", "c") apart from ("a", "bc")
+		fmt.Fprintf(h, "%v\x00", v)
+	}
+
+	return h.Sum64()
+}
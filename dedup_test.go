@@ -0,0 +1,63 @@
+package fileLogger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDeduplicateFiles(t *testing.T) {
+	dir := t.TempDir()
+	a := writeLogFile(t, dir, "a.log", func(l *FileLogger) {
+		l.I("one")
+		l.I("two")
+		l.I("three")
+	})
+	c := writeLogFile(t, dir, "c.log", func(l *FileLogger) {
+		l.I("two")
+		l.I("four")
+	})
+
+	// b.log repeats the last two entries of a.log, as a retry would, then logs again two and four
+	lines := strings.SplitAfter(readLog(t, a), "\n")
+	b := filepath.Join(dir, "b.log")
+	if err := os.WriteFile(b, []byte(lines[1]+lines[2]+readLog(t, c)), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		keyFields []string
+		removed   int
+		want      []string
+	}{
+		{"whole line", nil, 2, []string{"one", "two", "three", "two", "four"}},
+		{"time and msg", []string{"time", "msg"}, 2, []string{"one", "two", "three", "two", "four"}},
+		{"msg", []string{"msg"}, 3, []string{"one", "two", "three", "four"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dst bytes.Buffer
+			removed, err := DeduplicateFiles([]string{a, b}, &dst, tt.keyFields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if removed != tt.removed {
+				t.Errorf("%d duplicates removed, want %d", removed, tt.removed)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(dst.String(), "\n"), "\n")
+			if len(lines) != len(tt.want) {
+				t.Fatalf("deduplicated output is %q, want %d lines", lines, len(tt.want))
+			}
+			for i, line := range lines {
+				if e, err := ParseLine(line); err != nil || e.Msg != tt.want[i] {
+					t.Errorf("line %d is %q, want the entry %q", i, line, tt.want[i])
+				}
+			}
+		})
+	}
+}
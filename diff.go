@@ -0,0 +1,195 @@
+// Package: fileLogger
+// File: diff.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: log only what changed between two values, e.g. two versions of a config
+// DATE: 26-10-14 10:00
+package fileLogger
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+const (
+	DIFF_NONE = "<none>"
+)
+
+// WriteDiff writes name at level with a field per difference between before and after,
+// valued "<before> → <after>". Struct fields are compared recursively and keyed by their path,
+// e.g. DB.Host, unexported fields are skipped. A struct with an Equal method or without exported fields,
+// e.g. time.Time, is compared as a whole. Pointers are followed once, cyclic values end. Map entries are keyed path[key], a key missing on one side
+// is <none>. Slices and arrays are keyed by their path and valued "<removed elements> → <added elements>".
+// Nothing is written when before and after are equal
+func (f *FileLogger) WriteDiff(level LEVEL, name string, before, after interface{}) error {
+	_, file, line, _ := runtime.Caller(1) //calldepth=2
+	if !f.enabled(level) {
+		return nil
+	}
+
+	changes := make(map[string]string)
+	diffValue(changes, make(map[diffVisit]bool), "", reflect.ValueOf(before), reflect.ValueOf(after))
+	if len(changes) == 0 {
+		return nil
+	}
+
+	e := f.entry(level, file, line, name, nil)
+	for k, v := range changes {
+		if k == "" {
+			k = name
+		}
+		e.setField(k, v)
+	}
+
+	return f.send(e)
+}
+
+// a pair of pointers or maps already compared
+type diffVisit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+// record the differences between a and b under path into changes
+func diffValue(changes map[string]string, visited map[diffVisit]bool, path string, a, b reflect.Value) {
+	if a.Kind() == reflect.Ptr && seenDiff(visited, a, b) {
+		return
+	}
+	a, b = indirect(a), indirect(b)
+	if !a.IsValid() || !b.IsValid() || a.Type() != b.Type() {
+		if !a.IsValid() && !b.IsValid() {
+			return
+		}
+		changes[path] = fmt.Sprintf("%v → %v", diffString(a), diffString(b))
+		return
+	}
+	if a.Kind() == reflect.Map && seenDiff(visited, a, b) {
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		if a.CanInterface() && isLeafStruct(a) {
+			if !leafEqual(a, b) {
+				changes[path] = fmt.Sprintf("%v → %v", a.Interface(), b.Interface())
+			}
+			return
+		}
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			diffValue(changes, visited, joinDiffPath(path, field.Name), a.Field(i), b.Field(i))
+		}
+	case reflect.Map:
+		keys := a.MapKeys()
+		for _, k := range b.MapKeys() {
+			if !a.MapIndex(k).IsValid() {
+				keys = append(keys, k)
+			}
+		}
+		for _, k := range keys {
+			diffValue(changes, visited, fmt.Sprintf("%v[%v]", path, k.Interface()), a.MapIndex(k), b.MapIndex(k))
+		}
+	case reflect.Slice, reflect.Array:
+		removed, added := missingElements(a, b), missingElements(b, a)
+		if len(removed) > 0 || len(added) > 0 {
+			changes[path] = fmt.Sprintf("%v → %v", removed, added)
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			changes[path] = fmt.Sprintf("%v → %v", a.Interface(), b.Interface())
+		}
+	}
+}
+
+// report whether the pointers or maps a and b were already compared, and mark them compared
+func seenDiff(visited map[diffVisit]bool, a, b reflect.Value) bool {
+	if !b.IsValid() || a.Type() != b.Type() || a.IsNil() || b.IsNil() {
+		return false
+	}
+
+	visit := diffVisit{a.Pointer(), b.Pointer(), a.Type()}
+	if visited[visit] {
+		return true
+	}
+	visited[visit] = true
+
+	return false
+}
+
+// report whether the struct v is compared as a whole: it has an Equal method or no exported fields
+func isLeafStruct(v reflect.Value) bool {
+	if _, ok := equalMethod(v); ok {
+		return true
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).PkgPath == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// return the method Equal(T) bool of v of type T
+func equalMethod(v reflect.Value) (reflect.Value, bool) {
+	m := v.MethodByName("Equal")
+	if !m.IsValid() {
+		return m, false
+	}
+
+	t := m.Type()
+	return m, t.NumIn() == 1 && t.In(0) == v.Type() && t.NumOut() == 1 && t.Out(0).Kind() == reflect.Bool
+}
+
+// compare the leaf structs a and b with their Equal method, or reflect.DeepEqual without one
+func leafEqual(a, b reflect.Value) bool {
+	if equal, ok := equalMethod(a); ok {
+		return equal.Call([]reflect.Value{b})[0].Bool()
+	}
+
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// follow pointers and interfaces, a nil one gives the zero Value
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// return the elements of a not found in b, in the order of a
+func missingElements(a, b reflect.Value) []interface{} {
+	missing := []interface{}{}
+	for i := 0; i < a.Len(); i++ {
+		found := false
+		for j := 0; j < b.Len() && !found; j++ {
+			found = reflect.DeepEqual(a.Index(i).Interface(), b.Index(j).Interface())
+		}
+		if !found {
+			missing = append(missing, a.Index(i).Interface())
+		}
+	}
+
+	return missing
+}
+
+func diffString(v reflect.Value) string {
+	if !v.IsValid() {
+		return DIFF_NONE
+	}
+
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+func joinDiffPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
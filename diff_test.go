@@ -0,0 +1,90 @@
+package fileLogger
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type diffDB struct {
+	Host string
+	Port int
+}
+
+type diffConfig struct {
+	Name    string
+	DB      diffDB
+	Cache   *diffDB
+	Started time.Time
+	Tags    []string
+	Limits  map[string]int
+	secret  string
+}
+
+type diffNode struct {
+	Name string
+	Next *diffNode
+}
+
+func TestWriteDiff(t *testing.T) {
+	start := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+	base := diffConfig{
+		Name:    "app",
+		DB:      diffDB{"db1", 5432},
+		Cache:   &diffDB{"cache1", 6379},
+		Started: start,
+		Tags:    []string{"a", "b"},
+		Limits:  map[string]int{"rps": 10},
+		secret:  "s1",
+	}
+
+	cyclic := func(name string) *diffNode {
+		n := &diffNode{Name: name}
+		n.Next = n
+		return n
+	}
+
+	tests := []struct {
+		name          string
+		before, after interface{}
+		want          []string
+	}{
+		{"one field", base, func() diffConfig { c := base; c.Name = "app2"; return c }(), []string{"Name=app → app2"}},
+		{"nested", base, func() diffConfig { c := base; c.DB.Port = 5433; return c }(), []string{"DB.Port=5432 → 5433"}},
+		{"pointer", base, func() diffConfig { c := base; c.Cache = &diffDB{"cache2", 6379}; return c }(), []string{"Cache.Host=cache1 → cache2"}},
+		{"nil pointer", base, func() diffConfig { c := base; c.Cache = nil; return c }(), []string{"Cache={cache1 6379} → " + DIFF_NONE}},
+		{"time", base, func() diffConfig { c := base; c.Started = start.Add(time.Hour); return c }(), []string{"Started=" + start.String() + " → " + start.Add(time.Hour).String()}},
+		{"same instant", base, func() diffConfig { c := base; c.Started = start.In(time.FixedZone("X", 3600)); return c }(), nil},
+		{"map", base, func() diffConfig { c := base; c.Limits = map[string]int{"rps": 20, "burst": 5}; return c }(), []string{"Limits[rps]=10 → 20", "Limits[burst]=" + DIFF_NONE + " → 5"}},
+		{"slice", base, func() diffConfig { c := base; c.Tags = []string{"b", "c"}; return c }(), []string{"Tags=[a] → [c]"}},
+		{"unexported", base, func() diffConfig { c := base; c.secret = "s2"; return c }(), nil},
+		{"cycle", cyclic("a"), cyclic("b"), []string{"Name=a → b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			l := NewPlainLogger(dir, "a.log", "")
+			defer l.Close()
+
+			if err := l.WriteDiff(INFO, "config", tt.before, tt.after); err != nil {
+				t.Fatal(err)
+			}
+			l.Flush()
+
+			content := readLog(t, filepath.Join(dir, "a.log"))
+			if tt.want == nil && content != "" {
+				t.Errorf("a.log = %q, want nothing written", content)
+			}
+			if n := strings.Count(content, "="); tt.want != nil && n != len(tt.want) {
+				t.Errorf("a.log = %q, want only %v", content, tt.want)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(content, " "+want) {
+					t.Errorf("a.log = %q, want it to hold %q", content, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Package: fileLogger
+// File: entry.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: log entry passed from the log methods to logWriter
+// DATE: 26-10-14 06:00
+package fileLogger
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Entry holds a single log record on its way to the log file.
+// Entries made by Print(), Printf() and Println() carry Level OFF, they are not subject to the log level
+type Entry struct {
+	Level  LEVEL
+	Time   time.Time
+	File   string
+	Line   int
+	Msg    string
+	Fields map[string]interface{}
+
+	// fields of WriteFields, written after Fields
+	typed []Field
+
+	// set on the marker entry of Flush, closed by logWriter instead of writing the entry
+	flushed chan struct{}
+
+	// set on the copy route sends, which is not routed again
+	routed bool
+}
+
+// entries are reused to avoid a heap allocation per log call
+var entryPool = sync.Pool{
+	New: func() interface{} { return new(Entry) },
+}
+
+// get an entry from the pool and populate it
+func newEntry(level LEVEL, file string, line int, msg string) *Entry {
+	e := entryPool.Get().(*Entry)
+	e.Level = level
+	e.File = shortFileName(file)
+	e.Line = line
+	e.Msg = msg
+
+	return e
+}
+
+// zero e and return it to the pool, e must not be used afterwards.
+// Fields is emptied rather than dropped so the map itself is reused,
+// but no reference to the caller's values survives in the pool
+func freeEntry(e *Entry) {
+	for k := range e.Fields {
+		delete(e.Fields, k)
+	}
+	e.Level = TRACE
+	e.Time = time.Time{}
+	e.File = ""
+	e.Line = 0
+	e.Msg = ""
+	for i := range e.typed {
+		e.typed[i] = nil
+	}
+	e.typed = e.typed[:0]
+	e.flushed = nil
+	e.routed = false
+
+	entryPool.Put(e)
+}
+
+// clone returns a copy of e owning its own Fields, safe to keep after e went back to the pool
+func (e *Entry) clone() *Entry {
+	c := *e
+	if e.Fields != nil {
+		c.Fields = make(map[string]interface{}, len(e.Fields))
+		for k, v := range e.Fields {
+			c.Fields[k] = v
+		}
+	}
+	if e.typed != nil {
+		c.typed = append([]Field(nil), e.typed...)
+	}
+
+	return &c
+}
+
+// set a field, allocating Fields on first use
+func (e *Entry) setField(key string, value interface{}) {
+	if e.Fields == nil {
+		e.Fields = make(map[string]interface{})
+	}
+	e.Fields[key] = value
+}
+
+// addErrorFields records the first error in v as structured fields:
+// error_chain holds the type names from the outermost error down to the root cause,
+// *url.Error adds op, url and err, *os.PathError adds op and path
+func (e *Entry) addErrorFields(v []interface{}) {
+	var err error
+	for _, arg := range v {
+		if argErr, ok := arg.(error); ok {
+			err = argErr
+			break
+		}
+	}
+	if err == nil {
+		return
+	}
+
+	chain := []string{}
+	for cause := err; cause != nil; cause = errors.Unwrap(cause) {
+		chain = append(chain, fmt.Sprintf("%T", cause))
+	}
+	e.setField("error_chain", chain)
+
+	var urlErr *url.Error
+	var pathErr *os.PathError
+	if errors.As(err, &urlErr) {
+		e.setField("op", urlErr.Op)
+		e.setField("url", urlErr.URL)
+		e.setField("err", urlErr.Err.Error())
+	} else if errors.As(err, &pathErr) {
+		e.setField("op", pathErr.Op)
+		e.setField("path", pathErr.Path)
+	}
+}
+
+// return the keys of Fields in sorted order
+func (e *Entry) fieldKeys() []string {
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// String returns the entry as it is written after the logger's prefix and timestamp by TextFormatter,
+// leveled entries get a colored level tag
+func (e *Entry) String() string {
+	return e.text(true)
+}
+
+// format the entry as text, with or without the console colors of the level tag
+func (e *Entry) text(color bool) string {
+	str := fmt.Sprintf("[%v:%v]", e.File, e.Line)
+	if e.Level < OFF && color {
+		str += levelColors[e.Level] + "[" + e.Level.String() + "] " + e.Msg + " \033[0m"
+		// the reset is followed by a space, the one before the first field when there is one
+		if len(e.Fields) == 0 && len(e.typed) == 0 {
+			str += " "
+		}
+	} else if e.Level < OFF {
+		str += "[" + e.Level.String() + "] " + e.Msg
+	} else {
+		str += e.Msg
+	}
+
+	for _, k := range e.fieldKeys() {
+		str += fmt.Sprintf(" %v=%v", k, e.Fields[k])
+	}
+	if len(e.typed) > 0 {
+		str += string(e.appendTyped(nil))
+	}
+
+	return str
+}
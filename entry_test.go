@@ -0,0 +1,63 @@
+package fileLogger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestErrorTransformer(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.SetErrorTransformer(true)
+
+	_, err := os.Open(filepath.Join(dir, "missing"))
+	l.E("open: %v", err)
+	l.Flush()
+
+	content := readLog(t, filepath.Join(dir, "a.log"))
+	for _, want := range []string{"error_chain=[*fs.PathError", "op=open", "path=" + filepath.Join(dir, "missing")} {
+		if !strings.Contains(content, want) {
+			t.Errorf("a.log = %q, want it to hold %q", content, want)
+		}
+	}
+}
+
+func TestPackageAnnotation(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.SetPackageAnnotation(true)
+
+	l.I("entry")
+	l.Flush()
+
+	// the test is in the package itself, the first caller outside of it is testing
+	if content := readLog(t, filepath.Join(dir, "a.log")); !strings.Contains(content, "pkg=testing") {
+		t.Errorf("a.log = %q, want pkg=testing", content)
+	}
+}
+
+// the setters of the flags read by each log call may run while logging
+func TestSettersWhileLogging(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 100; n++ {
+			l.E("entry %v", errors.New("boom"))
+		}
+	}()
+	for n := 0; n < 100; n++ {
+		l.SetErrorTransformer(n%2 == 0)
+		l.SetPackageAnnotation(n%2 == 0)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,33 @@
+// Package: fileLogger
+// File: errors.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: error values returned by fileLogger, test them with errors.Is
+// DATE: 26-10-14 06:10
+package fileLogger
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNilLogger      = errors.New("fileLogger: nil logger")
+	ErrClosed         = errors.New("fileLogger: logger is closed")
+	ErrRotationFailed = errors.New("fileLogger: rotation failed")
+	ErrCircuitOpen    = errors.New("fileLogger: circuit breaker is open")
+	ErrInvalidPath    = errors.New("fileLogger: invalid path")
+	ErrMissingField   = errors.New("fileLogger: missing field")
+	ErrNotInitialized = errors.New("fileLogger: logger is not initialized")
+	ErrInvalidConfig  = errors.New("fileLogger: invalid config")
+
+	ErrDirectoryNotWritable = errors.New("fileLogger: log directory is not writable")
+	ErrMalformedLine        = errors.New("fileLogger: malformed log line")
+	ErrEntryNotIndexed      = errors.New("fileLogger: entry is not in the offset index")
+	ErrQuotaExceeded        = errors.New("fileLogger: log quota exceeded")
+	ErrLockTimeout          = errors.New("fileLogger: lock acquisition timed out")
+	ErrMissingEventName     = fmt.Errorf("%w: event has no name", ErrMissingField)
+	ErrInvalidMetric        = errors.New("fileLogger: invalid metric")
+	ErrWriterStopped        = errors.New("fileLogger: log writer stopped")
+	ErrWriteFailed          = errors.New("fileLogger: write failed")
+	ErrReadFailed           = errors.New("fileLogger: read failed")
+)
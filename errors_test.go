@@ -0,0 +1,140 @@
+package fileLogger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	newLogger := func(t *testing.T) (*FileLogger, string) {
+		dir := t.TempDir()
+		l := NewSizeLogger(dir, "a.log", "", 3, 1, MB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+		t.Cleanup(func() { l.Close() })
+		return l, dir
+	}
+
+	tests := []struct {
+		name  string
+		err   func(t *testing.T) error
+		want  error
+		cause error
+	}{
+		{"nil logger", func(t *testing.T) error { return (*FileLogger)(nil).Close() }, ErrNilLogger, nil},
+		{"closed", func(t *testing.T) error {
+			l, _ := newLogger(t)
+			l.Close()
+			return l.WriteString(INFO, "entry")
+		}, ErrClosed, nil},
+		{"not initialized", func(t *testing.T) error {
+			_, err := (&FileLogger{mu: new(sync.RWMutex)}).WriteTo(io.Discard)
+			return err
+		}, ErrNotInitialized, nil},
+		{"invalid path", func(t *testing.T) error {
+			_, err := NewLoggerFromConfig(Config{})
+			return err
+		}, ErrInvalidPath, nil},
+		{"invalid config", func(t *testing.T) error {
+			l, _ := newLogger(t)
+			return l.SetChecksumOnRotate("crc32")
+		}, ErrInvalidConfig, nil},
+		{"missing field", func(t *testing.T) error {
+			l, _ := newLogger(t)
+			return l.WriteEvent(INFO, Event{})
+		}, ErrMissingField, nil},
+		{"rotation failed", func(t *testing.T) error {
+			l, dir := newLogger(t)
+			os.Remove(filepath.Join(dir, "a.log"))
+			return l.Rotate()
+		}, ErrRotationFailed, fs.ErrNotExist},
+		{"write failed", func(t *testing.T) error {
+			l, dir := newLogger(t)
+			os.Remove(filepath.Join(dir, "a.log"))
+			_, err := l.WriteAt([]byte("x"), 0)
+			return err
+		}, ErrWriteFailed, fs.ErrNotExist},
+		{"read failed", func(t *testing.T) error {
+			l, dir := newLogger(t)
+			os.WriteFile(filepath.Join(dir, CHECKSUM_FILE), []byte("{"), 0666)
+			_, err := l.VerifyAll()
+			return err
+		}, ErrReadFailed, nil},
+		{"directory not writable", func(t *testing.T) error {
+			// a regular file stands in the way of the log directory
+			file := filepath.Join(t.TempDir(), "file")
+			os.WriteFile(file, nil, 0666)
+			_, err := NewLoggerFromConfig(Config{SplitType: SplitType_None, FileDir: filepath.Join(file, "logs"), FileName: "a.log"})
+			return err
+		}, ErrDirectoryNotWritable, nil},
+		{"malformed line", func(t *testing.T) error {
+			_, err := ParseLine("not a log line")
+			return err
+		}, ErrMalformedLine, nil},
+		{"entry not indexed", func(t *testing.T) error {
+			l, _ := newLogger(t)
+			_, err := l.SeekToEntry(0)
+			return err
+		}, ErrEntryNotIndexed, nil},
+		{"quota exceeded", func(t *testing.T) error {
+			l, _ := newLogger(t)
+			l.SetQuota(time.Hour, 1)
+			l.WriteString(INFO, "entry")
+			l.Flush()
+			return l.WriteString(INFO, "entry")
+		}, ErrQuotaExceeded, nil},
+		{"lock timeout", func(t *testing.T) error {
+			l, _ := newLogger(t)
+			l.SetLockTimeout(time.Millisecond)
+			holdLock(l, 50*time.Millisecond)
+			return l.WriteString(INFO, "entry")
+		}, ErrLockTimeout, nil},
+		{"missing event name", func(t *testing.T) error {
+			l, _ := newLogger(t)
+			return l.WriteEvent(INFO, Event{})
+		}, ErrMissingEventName, nil},
+		{"invalid metric", func(t *testing.T) error {
+			l, _ := newLogger(t)
+			return l.WriteMetric(INFO, Metric{})
+		}, ErrInvalidMetric, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err(t)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("error %v, want %v", err, tt.want)
+			}
+			if tt.cause != nil && !errors.Is(err, tt.cause) {
+				t.Errorf("error %v does not wrap %v", err, tt.cause)
+			}
+		})
+	}
+}
+
+// each sentinel, ErrCircuitOpen included though nothing returns it yet, is told apart from the others by errors.Is,
+// through the "%w: %w" wrapping of fileLogger
+func TestSentinelErrorsDistinct(t *testing.T) {
+	sentinels := []error{
+		ErrNilLogger, ErrClosed, ErrRotationFailed, ErrCircuitOpen, ErrInvalidPath, ErrMissingField,
+		ErrNotInitialized, ErrInvalidConfig, ErrDirectoryNotWritable, ErrMalformedLine, ErrEntryNotIndexed,
+		ErrQuotaExceeded, ErrLockTimeout, ErrInvalidMetric, ErrWriterStopped, ErrWriteFailed, ErrReadFailed,
+	}
+
+	for i, sentinel := range sentinels {
+		err := fmt.Errorf("%w: %w", sentinel, os.ErrNotExist)
+		for j, other := range sentinels {
+			if got := errors.Is(err, other); got != (i == j) {
+				t.Errorf("errors.Is(%v, %v) = %v, want %v", err, other, got, i == j)
+			}
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%v does not wrap os.ErrNotExist", err)
+		}
+	}
+}
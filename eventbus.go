@@ -0,0 +1,75 @@
+// Package: fileLogger
+// File: eventbus.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: subscribe to log entries as they are written
+// DATE: 26-10-14 08:40
+package fileLogger
+
+import (
+	"sync"
+)
+
+// EventBus hands a copy of each written entry to its subscribers
+type EventBus struct {
+	subscribers sync.Map
+}
+
+type subscriber struct {
+	mu     sync.Mutex
+	level  LEVEL
+	ch     chan Entry
+	closed bool
+}
+
+// EventBus returns the event bus of f
+func (f *FileLogger) EventBus() *EventBus {
+	return &f.bus
+}
+
+// Subscribe returns a channel receiving the written entries of at least level, and a func to unsubscribe.
+// Entries of Print(), Printf() and Println() carry OFF and are always received.
+// While the channel is full entries are skipped, the writer never waits for a subscriber
+func (b *EventBus) Subscribe(level LEVEL) (<-chan Entry, func()) {
+	sub := &subscriber{level: level, ch: make(chan Entry, DEFAULT_LOG_SEQ)}
+	b.subscribers.Store(sub, struct{}{})
+
+	unsubscribe := func() {
+		b.subscribers.Delete(sub)
+
+		sub.mu.Lock()
+		defer sub.mu.Unlock()
+
+		if !sub.closed {
+			sub.closed = true
+			close(sub.ch)
+		}
+	}
+
+	return sub.ch, unsubscribe
+}
+
+// publish a copy of e to the subscribers, e itself goes back to the entry pool
+func (b *EventBus) publish(e *Entry) {
+	var entry *Entry
+	b.subscribers.Range(func(key, _ interface{}) bool {
+		sub := key.(*subscriber)
+		if e.Level < sub.level {
+			return true
+		}
+
+		if entry == nil {
+			entry = e.clone()
+		}
+
+		sub.mu.Lock()
+		if !sub.closed {
+			select {
+			case sub.ch <- *entry:
+			default:
+			}
+		}
+		sub.mu.Unlock()
+
+		return true
+	})
+}
@@ -0,0 +1,80 @@
+package fileLogger
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEventBus(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	ch, unsubscribe := l.EventBus().Subscribe(TRACE)
+	defer unsubscribe()
+	warnings, unsubscribeWarnings := l.EventBus().Subscribe(WARN)
+	defer unsubscribeWarnings()
+
+	for n := 0; n < 10; n++ {
+		l.I("entry %d", n)
+	}
+	l.W("warning")
+	l.Flush()
+
+	for n := 0; n < 10; n++ {
+		if e := <-ch; e.Msg != fmt.Sprintf("entry %d", n) {
+			t.Errorf("entry %d received is %q", n, e.Msg)
+		}
+	}
+	if e := <-ch; e.Msg != "warning" {
+		t.Errorf("entry received is %q, want the warning", e.Msg)
+	}
+	if len(ch) != 0 {
+		t.Errorf("%d more entries received, want exactly the 11 written", len(ch))
+	}
+	if len(warnings) != 1 || (<-warnings).Level != WARN {
+		t.Error("the WARN subscriber did not receive only the warning")
+	}
+}
+
+// a subscriber not reading its channel loses the entries once it is full, the writer does not wait for it
+func TestEventBusFullChannel(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	ch, unsubscribe := l.EventBus().Subscribe(TRACE)
+	defer unsubscribe()
+
+	for n := 0; n < cap(ch)+10; n++ {
+		l.I("entry %d", n)
+	}
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ch) != cap(ch) {
+		t.Errorf("%d entries in the channel, want it full with %d", len(ch), cap(ch))
+	}
+	if e := <-ch; e.Msg != "entry 0" {
+		t.Errorf("first entry received is %q, want the oldest kept", e.Msg)
+	}
+}
+
+func TestEventBusUnsubscribe(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	ch, unsubscribe := l.EventBus().Subscribe(TRACE)
+	l.I("before")
+	l.Flush()
+	unsubscribe()
+	unsubscribe()
+	l.I("after")
+	l.Flush()
+
+	if e, ok := <-ch; !ok || e.Msg != "before" {
+		t.Errorf("received %q, %v, want the entry written before unsubscribing", e.Msg, ok)
+	}
+	if e, ok := <-ch; ok {
+		t.Errorf("received %q after unsubscribing, want the channel closed", e.Msg)
+	}
+}
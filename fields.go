@@ -0,0 +1,213 @@
+// Package: fileLogger
+// File: fields.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: typed fields formatted straight into the log line
+// DATE: 26-10-14 12:30
+package fileLogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field is a key and value written after an entry's message. AppendTo appends it to b as key=value,
+// quoting a value holding spaces, quotes or an equal sign in the manner of logfmt
+type Field interface {
+	AppendTo(b []byte) []byte
+}
+
+// jsonField is implemented by the fields JSONFormatter writes as a member of its own,
+// other fields are written as a string
+type jsonField interface {
+	AppendJSON(b []byte) []byte
+}
+
+type IntField struct {
+	Key   string
+	Value int64
+}
+
+type StringField struct {
+	Key   string
+	Value string
+}
+
+type BoolField struct {
+	Key   string
+	Value bool
+}
+
+type Float64Field struct {
+	Key   string
+	Value float64
+}
+
+// TimeField is written in RFC3339 with nanoseconds
+type TimeField struct {
+	Key   string
+	Value time.Time
+}
+
+// AnyField holds a value of any type, formatted with fmt or encoding/json
+type AnyField struct {
+	Key   string
+	Value interface{}
+}
+
+func (f IntField) AppendTo(b []byte) []byte {
+	return strconv.AppendInt(append(append(b, f.Key...), '='), f.Value, 10)
+}
+
+func (f IntField) AppendJSON(b []byte) []byte {
+	return strconv.AppendInt(appendJSONKey(b, f.Key), f.Value, 10)
+}
+
+func (f StringField) AppendTo(b []byte) []byte {
+	return appendLogfmtValue(append(append(b, f.Key...), '='), f.Value)
+}
+
+func (f StringField) AppendJSON(b []byte) []byte {
+	return appendJSONString(appendJSONKey(b, f.Key), f.Value)
+}
+
+func (f BoolField) AppendTo(b []byte) []byte {
+	return strconv.AppendBool(append(append(b, f.Key...), '='), f.Value)
+}
+
+func (f BoolField) AppendJSON(b []byte) []byte {
+	return strconv.AppendBool(appendJSONKey(b, f.Key), f.Value)
+}
+
+func (f Float64Field) AppendTo(b []byte) []byte {
+	return strconv.AppendFloat(append(append(b, f.Key...), '='), f.Value, 'g', -1, 64)
+}
+
+// NaN and infinities are not JSON numbers, they are written as strings
+func (f Float64Field) AppendJSON(b []byte) []byte {
+	b = appendJSONKey(b, f.Key)
+	if str := strconv.FormatFloat(f.Value, 'g', -1, 64); strings.ContainsAny(str, "IN") {
+		return appendJSONString(b, str)
+	}
+
+	return strconv.AppendFloat(b, f.Value, 'g', -1, 64)
+}
+
+func (f TimeField) AppendTo(b []byte) []byte {
+	return f.Value.AppendFormat(append(append(b, f.Key...), '='), time.RFC3339Nano)
+}
+
+func (f TimeField) AppendJSON(b []byte) []byte {
+	b = append(appendJSONKey(b, f.Key), '"')
+	return append(f.Value.AppendFormat(b, time.RFC3339Nano), '"')
+}
+
+func (f AnyField) AppendTo(b []byte) []byte {
+	return appendLogfmtValue(append(append(b, f.Key...), '='), fmt.Sprint(f.Value))
+}
+
+func (f AnyField) AppendJSON(b []byte) []byte {
+	value, err := json.Marshal(f.Value)
+	if err != nil {
+		return appendJSONString(appendJSONKey(b, f.Key), fmt.Sprint(f.Value))
+	}
+
+	return append(appendJSONKey(b, f.Key), value...)
+}
+
+// WriteFields writes msg at level followed by fields. Typed fields are formatted straight into the log line
+// instead of going through Fields like the values of WriteKV: they are not seen by transform hooks,
+// nor read back as typed values. It allocates less than WriteKV but is not allocation free, see PERFORMANCE.md
+func (f *FileLogger) WriteFields(level LEVEL, msg string, fields ...Field) error {
+	_, file, line, _ := runtime.Caller(1) //calldepth=2
+	if !f.enabled(level) {
+		return nil
+	}
+
+	e := f.entry(level, file, line, msg, nil)
+	e.typed = append(e.typed, fields...)
+
+	return f.send(e)
+}
+
+// append the typed fields of e to b as key=value, each after a space
+func (e *Entry) appendTyped(b []byte) []byte {
+	for _, field := range e.typed {
+		b = field.AppendTo(append(b, ' '))
+	}
+
+	return b
+}
+
+// append the typed fields of e to the JSON object b as its last members,
+// a reserved key is prefixed as JSONFormatter does for Fields
+func (e *Entry) appendTypedJSON(b []byte) []byte {
+	if len(e.typed) == 0 || len(b) == 0 || b[len(b)-1] != '}' {
+		return b
+	}
+
+	b = b[:len(b)-1]
+	for _, field := range e.typed {
+		if len(b) > 1 {
+			b = append(b, ',')
+		}
+		if jf, ok := field.(jsonField); ok {
+			b = appendJSONMember(b, jf.AppendJSON(nil))
+		} else {
+			text := string(field.AppendTo(nil))
+			key, value := text, ""
+			if i := strings.Index(text, "="); i >= 0 {
+				key, value = text[:i], text[i+1:]
+			}
+			b = appendJSONString(appendJSONKey(b, jsonFieldKey(key)), value)
+		}
+	}
+
+	return append(b, '}')
+}
+
+// append the JSON member m to b, prefixing its key when it is reserved. Reserved keys need no escaping
+func appendJSONMember(b, m []byte) []byte {
+	if len(m) > 0 && m[0] == '"' {
+		if end := bytes.IndexByte(m[1:], '"'); end >= 0 && reservedJSONKeys[string(m[1:1+end])] {
+			return append(append(append(b, '"'), RESERVED_FIELD_PREFIX...), m[1:]...)
+		}
+	}
+
+	return append(b, m...)
+}
+
+func appendJSONKey(b []byte, key string) []byte {
+	return append(appendJSONString(b, key), ':')
+}
+
+func appendJSONString(b []byte, s string) []byte {
+	if !needsEscape(s) {
+		return append(append(append(b, '"'), s...), '"')
+	}
+
+	quoted, _ := json.Marshal(s)
+	return append(b, quoted...)
+}
+
+func needsEscape(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 0x20 || c >= 0x7f || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' {
+			return true
+		}
+	}
+
+	return false
+}
+
+func appendLogfmtValue(b []byte, v string) []byte {
+	if v == "" || strings.ContainsAny(v, " \t\"=\n") {
+		return strconv.AppendQuote(b, v)
+	}
+
+	return append(b, v...)
+}
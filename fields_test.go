@@ -0,0 +1,42 @@
+package fileLogger
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFieldFormats(t *testing.T) {
+	at := time.Date(2026, 10, 14, 8, 0, 0, 5, time.UTC)
+	tests := []struct {
+		field   Field
+		logfmt  string
+		jsonObj string
+	}{
+		{IntField{"n", -42}, "n=-42", `{"n":-42}`},
+		{StringField{"s", "plain"}, "s=plain", `{"s":"plain"}`},
+		{StringField{"s", `two "words"`}, `s="two \"words\""`, `{"s":"two \"words\""}`},
+		{StringField{"s", ""}, `s=""`, `{"s":""}`},
+		{BoolField{"ok", true}, "ok=true", `{"ok":true}`},
+		{Float64Field{"f", 0.25}, "f=0.25", `{"f":0.25}`},
+		{Float64Field{"f", math.Inf(1)}, "f=+Inf", `{"f":"+Inf"}`},
+		{TimeField{"at", at}, "at=2026-10-14T08:00:00.000000005Z", `{"at":"2026-10-14T08:00:00.000000005Z"}`},
+		{AnyField{"tags", []string{"a", "b"}}, `tags="[a b]"`, `{"tags":["a","b"]}`},
+	}
+
+	for _, tt := range tests {
+		if got := string(tt.field.AppendTo(nil)); got != tt.logfmt {
+			t.Errorf("%#v.AppendTo() = %v, want %v", tt.field, got, tt.logfmt)
+		}
+
+		e := &Entry{typed: []Field{tt.field}}
+		got := string(e.appendTypedJSON([]byte("{}")))
+		if got != tt.jsonObj {
+			t.Errorf("%#v in JSON = %v, want %v", tt.field, got, tt.jsonObj)
+		}
+		if !json.Valid([]byte(got)) {
+			t.Errorf("%#v in JSON = %v, not valid JSON", tt.field, got)
+		}
+	}
+}
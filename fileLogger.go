@@ -6,10 +6,14 @@
 package fileLogger
 
 import (
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"runtime"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -38,6 +42,8 @@ type SplitType byte
 const (
 	SplitType_Size SplitType = iota
 	SplitType_Daily
+	SplitType_HybridScheduled
+	SplitType_None
 )
 
 type LEVEL byte
@@ -50,8 +56,45 @@ const (
 	OFF
 )
 
+var levelNames = [...]string{
+	TRACE: "TRACE",
+	INFO:  "INFO",
+	WARN:  "WARN",
+	ERROR: "ERROR",
+	OFF:   "OFF",
+}
+
+// console color of each level's tag
+var levelColors = [...]string{
+	TRACE: "\033[32m",
+	INFO:  "\033[1;35m",
+	WARN:  "\033[1;33m",
+	ERROR: "\033[1;4;31m",
+}
+
+// String returns the level's name, such as "INFO"
+func (l LEVEL) String() string {
+	if int(l) < len(levelNames) {
+		return levelNames[l]
+	}
+
+	return "LEVEL(" + strconv.Itoa(int(l)) + ")"
+}
+
+// parseLevel returns the level named name, the reverse of LEVEL.String()
+func parseLevel(name string) (LEVEL, bool) {
+	for l, n := range levelNames {
+		if n == name {
+			return LEVEL(l), true
+		}
+	}
+
+	return OFF, false
+}
+
 type FileLogger struct {
 	splitType SplitType
+	// log writes take the read lock, split and reopening the log file the write lock, see PERFORMANCE.md
 	mu        *sync.RWMutex
 	fileDir   string
 	fileName  string
@@ -60,17 +103,95 @@ type FileLogger struct {
 	fileSize  int64
 	prefix    string
 
+	peakStart   time.Duration
+	peakEnd     time.Duration
+	peakSize    int64
+	offPeakSize int64
+
 	date *time.Time
+	// read by the log calls without f.mu, which a split may hold
+	loc atomic.Pointer[time.Location]
 
-	logFile *os.File
-	lg      *log.Logger
+	hostnameSubdir bool
 
-	logScan int64
+	// owner of the log files, applied when owned is set
+	owned bool
+	uid   int
+	gid   int
+
+	logFile    *os.File
+	flag       int
+	lineEnding string
+	buf        []byte
 
-	logChan chan string
+	logScan int64
 
-	logLevel   LEVEL
-	logConsole bool
+	logChan chan *Entry
+	// closed by Close to stop fileMonitor and the sends to logChan, logWriter stops once logChan is drained
+	done chan struct{}
+	// closed when logWriter returns, after Close or a panic
+	stopped chan struct{}
+
+	logLevel          LEVEL
+	logConsole        bool
+	errorTransformer  atomic.Bool
+	packageAnnotation atomic.Bool
+	packageLevels     map[string]LEVEL
+	sensitiveHeaders  map[string]bool
+	formatter         Formatter
+	transformHooks    []TransformHook
+	entryHeaders      []EntryHeader
+
+	closed       bool
+	startupCheck bool
+
+	levelMu       sync.Mutex
+	levelCallback func(LevelChangeEvent)
+	levelChans    map[chan LevelChangeEvent]struct{}
+
+	bus    EventBus
+	syslog *syslogSink
+	memory ringBuffer
+	tee    *httpTee
+
+	router    map[string]*FileLogger
+	routeMode PrefixRouteMode
+
+	// read by the log calls without f.mu
+	ctxKey   atomic.Pointer[interface{}]
+	contexts sync.Map
+
+	checksumAlgorithm string
+	rotationLock      bool
+	splitOnError      bool
+	dateLinks         bool
+	linkDir           string
+
+	offsetIndex bool
+	indexMu     sync.Mutex
+	index       []int64
+
+	slowWriteThreshold time.Duration
+	slowWriteCount     atomic.Uint64
+	maxWriteLatency    atomic.Int64
+
+	quota             *quota
+	levelQuotas       map[LEVEL]*quota
+	quotaDroppedCount atomic.Uint64
+
+	lockTimeout      atomic.Int64
+	lockTimeoutCount atomic.Uint64
+
+	// logLevel and the lowest level of SetPackageLevelOverride, read by each log call without a lock
+	logLevelValue   atomic.Int32
+	packageLevelMin atomic.Int32
+
+	httpTeeTimeout    atomic.Int64
+	httpTeeErrorCount atomic.Uint64
+
+	entriesWritten   atomic.Uint64
+	bytesWritten     atomic.Uint64
+	throughputReport chan struct{}
 }
 
 // NewDefaultLogger return a logger split by fileSize by default
@@ -79,6 +200,18 @@ func NewDefaultLogger(fileDir, fileName string) *FileLogger {
 		DEFAULT_FILE_COUNT, DEFAULT_FILE_SIZE, DEFAULT_FILE_UNIT, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
 }
 
+// NewMBLogger return a logger split by fileSize, each bak file holds fileMaxMB megabytes
+func NewMBLogger(fileDir, fileName, prefix string, fileCount int, fileMaxMB int) *FileLogger {
+	return NewSizeLogger(fileDir, fileName, prefix,
+		fileCount, int64(fileMaxMB), MB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+}
+
+// NewGBLogger return a logger split by fileSize, each bak file holds fileMaxGB gigabytes
+func NewGBLogger(fileDir, fileName, prefix string, fileCount int, fileMaxGB int) *FileLogger {
+	return NewSizeLogger(fileDir, fileName, prefix,
+		fileCount, int64(fileMaxGB), GB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+}
+
 // NewSizeLogger return a logger split by fileSize
 // Parameters:
 // 		file directory
@@ -90,115 +223,292 @@ func NewDefaultLogger(fileDir, fileName string) *FileLogger {
 //		logScan after a logScan time will check fileLogger isMustSplit, default is 300s
 func NewSizeLogger(fileDir, fileName, prefix string, fileCount int, fileSize int64, unit UNIT,
 	logScan int64, logSeq int) *FileLogger {
-	sizeLogger := &FileLogger{
-		splitType:  SplitType_Size,
-		mu:         new(sync.RWMutex),
-		fileDir:    fileDir,
-		fileName:   fileName,
-		fileCount:  fileCount,
-		fileSize:   fileSize * int64(unit),
-		prefix:     prefix,
-		logScan:    logScan,
-		logChan:    make(chan string, logSeq),
-		logLevel:   DEFAULT_LOG_LEVEL,
-		logConsole: false,
-	}
-
-	sizeLogger.initLogger()
+	sizeLogger := newSizeLogger(fileDir, fileName, prefix, fileCount, fileSize, unit, logScan, logSeq)
+
+	if err := sizeLogger.initLogger(); err != nil {
+		log.Printf("FileLogger init error: %v", err)
+	}
 
 	return sizeLogger
 }
 
+// newSizeLogger returns the logger of NewSizeLogger before its log file is opened and its goroutines are started
+func newSizeLogger(fileDir, fileName, prefix string, fileCount int, fileSize int64, unit UNIT,
+	logScan int64, logSeq int) *FileLogger {
+	return &FileLogger{
+		splitType:    SplitType_Size,
+		mu:           new(sync.RWMutex),
+		fileDir:      fileDir,
+		fileName:     fileName,
+		fileCount:    fileCount,
+		fileSize:     fileSize * int64(unit),
+		prefix:       prefix,
+		logScan:      logScan,
+		logChan:      make(chan *Entry, logSeq),
+		logLevel:     DEFAULT_LOG_LEVEL,
+		logConsole:   false,
+		flag:         log.LstdFlags | log.Lmicroseconds,
+		startupCheck: true,
+	}
+}
+
 // NewDailyLogger return a logger split by daily
 // Parameters:
 // 		file directory
 // 		file name
 // 		log's prefix
 func NewDailyLogger(fileDir, fileName, prefix string, logScan int64, logSeq int) *FileLogger {
-	dailyLogger := &FileLogger{
-		splitType:  SplitType_Daily,
-		mu:         new(sync.RWMutex),
-		fileDir:    fileDir,
-		fileName:   fileName,
-		prefix:     prefix,
-		logScan:    logScan,
-		logChan:    make(chan string, logSeq),
-		logLevel:   DEFAULT_LOG_LEVEL,
-		logConsole: false,
-	}
+	dailyLogger := newDailyLogger(fileDir, fileName, prefix, logScan, logSeq)
 
-	dailyLogger.initLogger()
+	if err := dailyLogger.initLogger(); err != nil {
+		log.Printf("FileLogger init error: %v", err)
+	}
 
 	return dailyLogger
 }
 
-func (f *FileLogger) initLogger() {
+// newDailyLogger returns the logger of NewDailyLogger before its log file is opened and its goroutines are started
+func newDailyLogger(fileDir, fileName, prefix string, logScan int64, logSeq int) *FileLogger {
+	return &FileLogger{
+		splitType:    SplitType_Daily,
+		mu:           new(sync.RWMutex),
+		fileDir:      fileDir,
+		fileName:     fileName,
+		prefix:       prefix,
+		logScan:      logScan,
+		logChan:      make(chan *Entry, logSeq),
+		logLevel:     DEFAULT_LOG_LEVEL,
+		logConsole:   false,
+		flag:         log.LstdFlags | log.Lmicroseconds,
+		startupCheck: true,
+	}
+}
+
+// NewHybridScheduledLogger return a logger split by fileSize, the size threshold depends on the time of day
+// Parameters:
+// 		file directory
+// 		file name
+// 		log's prefix
+// 		peakStart, peakEnd holds the peak hours as offset from midnight
+// 		peakSize holds each of bak file's size out of the peak hours
+// 		offPeakSize holds each of bak file's size during the peak hours
+// 		unit stands for kb, mb, gb, tb
+func NewHybridScheduledLogger(fileDir, fileName, prefix string, peakStart, peakEnd time.Duration,
+	peakSize, offPeakSize int64, unit UNIT) *FileLogger {
+	hybridLogger := newHybridScheduledLogger(fileDir, fileName, prefix, peakStart, peakEnd, peakSize, offPeakSize, unit)
+
+	if err := hybridLogger.initLogger(); err != nil {
+		log.Printf("FileLogger init error: %v", err)
+	}
+
+	return hybridLogger
+}
+
+// newHybridScheduledLogger returns the logger of NewHybridScheduledLogger before its log file is opened and its goroutines are started
+func newHybridScheduledLogger(fileDir, fileName, prefix string, peakStart, peakEnd time.Duration,
+	peakSize, offPeakSize int64, unit UNIT) *FileLogger {
+	return &FileLogger{
+		splitType:    SplitType_HybridScheduled,
+		mu:           new(sync.RWMutex),
+		fileDir:      fileDir,
+		fileName:     fileName,
+		fileCount:    DEFAULT_FILE_COUNT,
+		prefix:       prefix,
+		peakStart:    peakStart,
+		peakEnd:      peakEnd,
+		peakSize:     peakSize * int64(unit),
+		offPeakSize:  offPeakSize * int64(unit),
+		logScan:      DEFAULT_LOG_SCAN,
+		logChan:      make(chan *Entry, DEFAULT_LOG_SEQ),
+		logLevel:     DEFAULT_LOG_LEVEL,
+		logConsole:   false,
+		flag:         log.LstdFlags | log.Lmicroseconds,
+		startupCheck: true,
+	}
+}
+
+// NewPlainLogger return a logger never split, for log files rotated outside the application
+// Parameters:
+// 		file directory
+// 		file name
+// 		log's prefix
+func NewPlainLogger(fileDir, fileName, prefix string) *FileLogger {
+	plainLogger := newPlainLogger(fileDir, fileName, prefix)
+
+	if err := plainLogger.initLogger(); err != nil {
+		log.Printf("FileLogger init error: %v", err)
+	}
+
+	return plainLogger
+}
+
+// newPlainLogger returns the logger of NewPlainLogger before its log file is opened and its goroutines are started
+func newPlainLogger(fileDir, fileName, prefix string) *FileLogger {
+	return &FileLogger{
+		splitType:    SplitType_None,
+		mu:           new(sync.RWMutex),
+		fileDir:      fileDir,
+		fileName:     fileName,
+		prefix:       prefix,
+		logChan:      make(chan *Entry, DEFAULT_LOG_SEQ),
+		logLevel:     DEFAULT_LOG_LEVEL,
+		logConsole:   false,
+		flag:         log.LstdFlags | log.Lmicroseconds,
+		startupCheck: true,
+	}
+}
+
+func (f *FileLogger) initLogger() error {
+	f.done = make(chan struct{})
+	f.stopped = make(chan struct{})
+	f.logLevelValue.Store(int32(f.logLevel))
+	f.packageLevelMin.Store(int32(OFF))
 
 	switch f.splitType {
-	case SplitType_Size:
-		f.initLoggerBySize()
+	case SplitType_Size, SplitType_HybridScheduled:
+		return f.initLoggerBySize()
 	case SplitType_Daily:
-		f.initLoggerByDaily()
+		return f.initLoggerByDaily()
+	case SplitType_None:
+		return f.initLoggerPlain()
 	}
 
+	return nil
 }
 
 // init filelogger split by fileSize
-func (f *FileLogger) initLoggerBySize() {
+func (f *FileLogger) initLoggerBySize() error {
 
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	logFile := joinFilePath(f.fileDir, f.fileName)
-	for i := 1; i <= f.fileCount; i++ {
-		if !isExist(logFile + "." + strconv.Itoa(i)) {
-			break
-		}
-
-		f.suffix = i
-	}
-
-	if !f.isMustSplit() {
-		if !isExist(f.fileDir) {
-			os.Mkdir(f.fileDir, 0755)
-		}
-		f.logFile, _ = os.OpenFile(logFile, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0666)
-		f.lg = log.New(f.logFile, f.prefix, log.LstdFlags|log.Lmicroseconds)
-	} else {
-		f.split()
-	}
+	f.loadSuffix()
+	err := f.openLogFile()
 
 	go f.logWriter()
 	go f.fileMonitor()
+
+	return err
 }
 
 // init fileLogger split by daily
-func (f *FileLogger) initLoggerByDaily() {
+func (f *FileLogger) initLoggerByDaily() error {
 
-	t, _ := time.Parse(DATEFORMAT, time.Now().Format(DATEFORMAT))
+	t, _ := time.Parse(DATEFORMAT, f.now().Format(DATEFORMAT))
 
 	f.date = &t
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
+	err := f.openLogFile()
+
+	go f.logWriter()
+	go f.fileMonitor()
+
+	return err
+}
+
+// init fileLogger never split, no fileMonitor is needed
+func (f *FileLogger) initLoggerPlain() error {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := f.openLogFile()
+
+	go f.logWriter()
+
+	return err
+}
+
+// find the suffix of the last written bak file of a fileLogger split by fileSize.
+// Once the suffixes have wrapped around fileCount the highest existing suffix is not the last one,
+// and a missing bak file must not hide the ones after it, so every suffix is checked by mod time
+func (f *FileLogger) loadSuffix() {
+	f.suffix = 0
+
+	var last time.Time
+	logFile := joinFilePath(f.fileDir, f.fileName)
+	for i := 1; i <= f.fileCount; i++ {
+		info, err := os.Stat(logFile + "." + strconv.Itoa(i))
+		if err != nil {
+			continue
+		}
+
+		if f.suffix == 0 || info.ModTime().After(last) {
+			f.suffix = i
+			last = info.ModTime()
+		}
+	}
+}
+
+// open the current log file in fileDir, or split it when it is already time to.
+// With startupCheck on, a fileDir or log file that is not writable is reported, the file is opened anyway
+func (f *FileLogger) openLogFile() error {
+
 	logFile := joinFilePath(f.fileDir, f.fileName)
+
+	var err error
+	if f.startupCheck {
+		err = checkWritable(f.fileDir, logFile)
+	}
+
 	if !f.isMustSplit() {
 		if !isExist(f.fileDir) {
-			os.Mkdir(f.fileDir, 0755)
+			os.MkdirAll(f.fileDir, 0755)
 		}
 		f.logFile, _ = os.OpenFile(logFile, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0666)
-		f.lg = log.New(f.logFile, f.prefix, log.LstdFlags|log.Lmicroseconds)
-	} else {
-		f.split()
+		f.chown(logFile)
+	} else if err := f.split(); err != nil {
+		f.printf("FileLogger split error: %v", err)
 	}
 
-	go f.logWriter()
-	go f.fileMonitor()
+	return err
+}
+
+// chown the log file to the owner set by SetOwner, chown is not supported on windows
+func (f *FileLogger) chown(logFile string) {
+	if !f.owned || runtime.GOOS == "windows" {
+		return
+	}
+
+	if err := os.Chown(logFile, f.uid, f.gid); err != nil {
+		f.printf("FileLogger chown error: %v", err)
+	}
+}
+
+// now returns the current time in the fileLogger's timezone
+func (f *FileLogger) now() time.Time {
+	if loc := f.loc.Load(); loc != nil {
+		return time.Now().In(loc)
+	}
+
+	return time.Now()
+}
+
+// isPeak reports whether the current time of day falls in [peakStart, peakEnd),
+// a peakEnd before peakStart means the peak hours wrap around midnight
+func (f *FileLogger) isPeak() bool {
+	now := f.now()
+	y, m, d := now.Date()
+
+	return inPeak(now.Sub(time.Date(y, m, d, 0, 0, 0, 0, now.Location())), f.peakStart, f.peakEnd)
+}
+
+// inPeak reports whether offset from midnight falls in [peakStart, peakEnd)
+func inPeak(offset, peakStart, peakEnd time.Duration) bool {
+	if peakStart <= peakEnd {
+		return offset >= peakStart && offset < peakEnd
+	}
+
+	return offset >= peakStart || offset < peakEnd
 }
 
 // used for determine the fileLogger f is time to split.
 // size: once the current fileLogger's fileSize >= config.fileSize need to split
 // daily: once the current fileLogger stands for yesterday need to split
+// hybridScheduled: same as size, using offPeakSize during the peak hours and peakSize otherwise
+// none: never
 func (f *FileLogger) isMustSplit() bool {
 
 	switch f.splitType {
@@ -209,8 +519,19 @@ func (f *FileLogger) isMustSplit() bool {
 				return true
 			}
 		}
+	case SplitType_HybridScheduled:
+		logFile := joinFilePath(f.fileDir, f.fileName)
+		threshold := f.peakSize
+		if f.isPeak() {
+			threshold = f.offPeakSize
+		}
+		if f.fileCount > 1 {
+			if fileSize(logFile) >= threshold {
+				return true
+			}
+		}
 	case SplitType_Daily:
-		t, _ := time.Parse(DATEFORMAT, time.Now().Format(DATEFORMAT))
+		t, _ := time.Parse(DATEFORMAT, f.now().Format(DATEFORMAT))
 		if t.After(*f.date) {
 			return true
 		}
@@ -219,13 +540,31 @@ func (f *FileLogger) isMustSplit() bool {
 	return false
 }
 
-// Split fileLogger
-func (f *FileLogger) split() {
+// Split fileLogger, a failed rename keeps appending to the current log file.
+// A fileLogger of SplitType_None is never split
+func (f *FileLogger) split() error {
 
 	logFile := joinFilePath(f.fileDir, f.fileName)
 
+	if f.rotationLock && f.splitType != SplitType_None {
+		if !lockRotation(logFile) {
+			f.skipSplit(logFile)
+			return nil
+		}
+		defer os.Remove(logFile + ROTATION_LOCK_SUFFIX)
+	}
+
 	switch f.splitType {
-	case SplitType_Size:
+	case SplitType_Size, SplitType_HybridScheduled:
+		if f.rotationLock {
+			// another fileLogger may have split it between the size check and the lock
+			if f.isSplitAway() {
+				f.skipSplit(logFile)
+				return nil
+			}
+			// the last bak file may have been written by another fileLogger
+			f.loadSuffix()
+		}
 		f.suffix = int(f.suffix%f.fileCount + 1)
 		if f.logFile != nil {
 			f.logFile.Close()
@@ -235,47 +574,150 @@ func (f *FileLogger) split() {
 		if isExist(logFileBak) {
 			os.Remove(logFileBak)
 		}
-		os.Rename(logFile, logFileBak)
+		if err := os.Rename(logFile, logFileBak); err != nil {
+			f.logFile, _ = os.OpenFile(logFile, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0666)
+			f.chown(logFile)
+			return fmt.Errorf("%w: %w", ErrRotationFailed, err)
+		}
 
-		f.logFile, _ = os.Create(logFile)
-		f.lg = log.New(f.logFile, f.prefix, log.LstdFlags|log.Lmicroseconds)
+		// appending, another fileLogger of the same file may have created it since the rename
+		f.logFile, _ = os.OpenFile(logFile, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0666)
+		f.chown(logFile)
+		f.afterSplit(logFileBak)
 
 	case SplitType_Daily:
 		logFileBak := logFile + "." + f.date.Format(DATEFORMAT)
-		if !isExist(logFileBak) && f.isMustSplit() {
+		if f.rotationLock && isExist(logFileBak) && f.isMustSplit() {
+			// split by another fileLogger
+			f.skipSplit(logFile)
+		} else if !isExist(logFileBak) && f.isMustSplit() {
 			if f.logFile != nil {
 				f.logFile.Close()
 			}
 
-			err := os.Rename(logFile, logFileBak)
-			if err != nil {
-				f.lg.Printf("FileLogger rename error: %v", err.Error())
+			if err := os.Rename(logFile, logFileBak); err != nil {
+				f.logFile, _ = os.OpenFile(logFile, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0666)
+				f.chown(logFile)
+				return fmt.Errorf("%w: %w", ErrRotationFailed, err)
 			}
 
-			t, _ := time.Parse(DATEFORMAT, time.Now().Format(DATEFORMAT))
+			t, _ := time.Parse(DATEFORMAT, f.now().Format(DATEFORMAT))
 			f.date = &t
-			f.logFile, _ = os.Create(logFile)
-			f.lg = log.New(f.logFile, f.prefix, log.LstdFlags|log.Lmicroseconds)
+			f.logFile, _ = os.OpenFile(logFile, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0666)
+			f.chown(logFile)
+			f.afterSplit(logFileBak)
 		}
 	}
+
+	return nil
+}
+
+// Rotate splits the log file now, whatever its size or date. The bak file of a daily logger is named
+// <logFile>.<date>.<n>, n counting the rotations of the day from 1. A fileLogger of SplitType_None,
+// or split by size with a fileCount below 1, can not be rotated
+func (f *FileLogger) Rotate() error {
+	if f == nil {
+		return ErrNilLogger
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return ErrClosed
+	}
+
+	switch f.splitType {
+	case SplitType_Size, SplitType_HybridScheduled:
+		if f.fileCount < 1 {
+			return fmt.Errorf("%w: fileCount is %v, no bak file is kept", ErrRotationFailed, f.fileCount)
+		}
+		return f.split()
+	case SplitType_Daily:
+		return f.rotateDaily()
+	}
+
+	return fmt.Errorf("%w: a fileLogger of SplitType_None is never split", ErrRotationFailed)
+}
+
+// split a daily log file before the end of its day, f.mu is held
+func (f *FileLogger) rotateDaily() error {
+	logFile := joinFilePath(f.fileDir, f.fileName)
+
+	logFileBak := ""
+	for n := 1; logFileBak == "" || isExist(logFileBak); n++ {
+		logFileBak = logFile + "." + f.date.Format(DATEFORMAT) + "." + strconv.Itoa(n)
+	}
+
+	if f.logFile != nil {
+		f.logFile.Close()
+	}
+	if err := os.Rename(logFile, logFileBak); err != nil {
+		f.logFile, _ = os.OpenFile(logFile, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0666)
+		f.chown(logFile)
+		return fmt.Errorf("%w: %w", ErrRotationFailed, err)
+	}
+
+	f.logFile, _ = os.OpenFile(logFile, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0666)
+	f.chown(logFile)
+	f.afterSplit(logFileBak)
+
+	return nil
+}
+
+// work on the new bak file once split is done, f.mu is still held
+func (f *FileLogger) afterSplit(logFileBak string) {
+	if f.offsetIndex {
+		f.resetIndex()
+	}
+	if f.checksumAlgorithm != "" {
+		if err := f.recordChecksum(logFileBak); err != nil {
+			f.printf("FileLogger checksum error: %v", err)
+		}
+	}
+	if f.dateLinks && (f.splitType == SplitType_Size || f.splitType == SplitType_HybridScheduled) {
+		if err := f.dateLink(logFileBak); err != nil {
+			f.printf("FileLogger date link error: %v", err)
+		}
+	}
+}
+
+// hard link the bak file of a size split to linkDir/<fileName>.<date>.<suffix>, replacing the link
+// of an earlier split of the day to the same suffix, f.mu is held
+func (f *FileLogger) dateLink(logFileBak string) error {
+	if err := os.MkdirAll(f.linkDir, 0755); err != nil {
+		return err
+	}
+
+	link := joinFilePath(f.linkDir, f.fileName+"."+f.now().Format(DATEFORMAT)+"."+strconv.Itoa(f.suffix))
+	if isExist(link) {
+		os.Remove(link)
+	}
+
+	return os.Link(logFileBak, link)
 }
 
 // After some interval time, goto check the current fileLogger's size or date
 func (f *FileLogger) fileMonitor() {
 	defer func() {
 		if err := recover(); err != nil {
-			f.lg.Printf("FileLogger's FileMonitor() catch panic: %v\n", err)
+			f.printf("FileLogger's FileMonitor() catch panic: %v\n", err)
 		}
 	}()
 
-	//TODO  load logScan interval from config file
-	logScan := DEFAULT_LOG_SCAN
+	logScan := f.logScan
+	if logScan <= 0 {
+		logScan = DEFAULT_LOG_SCAN
+	}
 
 	timer := time.NewTicker(time.Duration(logScan) * time.Second)
+	defer timer.Stop()
 	for {
 		select {
 		case <-timer.C:
 			f.fileCheck()
+		case <-f.done:
+			return
 		}
 	}
 }
@@ -284,7 +726,7 @@ func (f *FileLogger) fileMonitor() {
 func (f *FileLogger) fileCheck() {
 	defer func() {
 		if err := recover(); err != nil {
-			f.lg.Printf("FileLogger's FileCheck() catch panic: %v\n", err)
+			f.printf("FileLogger's FileCheck() catch panic: %v\n", err)
 		}
 	}()
 
@@ -292,15 +734,148 @@ func (f *FileLogger) fileCheck() {
 		f.mu.Lock()
 		defer f.mu.Unlock()
 
-		f.split()
+		if err := f.split(); err != nil {
+			f.printf("FileLogger split error: %v", err)
+		}
+	} else if f.rotationLock {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if f.isSplitAway() {
+			f.skipSplit(joinFilePath(f.fileDir, f.fileName))
+		}
 	}
 }
 
-// passive to close fileLogger
+// passive to close fileLogger, once the entries queued before the call are written
 func (f *FileLogger) Close() error {
+	if f == nil {
+		return ErrNilLogger
+	}
+
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return ErrClosed
+	}
+	if f.logFile == nil {
+		f.mu.Unlock()
+		return ErrNotInitialized
+	}
+	f.closed = true
+	close(f.done)
+	f.mu.Unlock()
 
-	close(f.logChan)
-	f.lg = nil
+	// logWriter takes the lock to write the entries left in logChan
+	<-f.stopped
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.offsetIndex {
+		f.saveIndex()
+	}
+	if err := f.logFile.Close(); err != nil {
+		return fmt.Errorf("%w: %w", ErrWriteFailed, err)
+	}
+
+	return nil
+}
+
+// Flush blocks until every entry queued before the call is written, then syncs the log file to disk.
+// Returns ErrClosed once f is closed and ErrWriterStopped when nothing is left to write the entries
+func (f *FileLogger) Flush() error {
+	if f == nil {
+		return ErrNilLogger
+	}
+
+	f.mu.RLock()
+	closed := f.closed
+	f.mu.RUnlock()
+	if closed {
+		return ErrClosed
+	}
+
+	e := entryPool.Get().(*Entry)
+	done := make(chan struct{})
+	e.flushed = done
+	if err := f.queue(e); err != nil {
+		return err
+	}
+	select {
+	case <-done:
+	case <-f.done:
+		return ErrClosed
+	case <-f.stopped:
+		return ErrWriterStopped
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.logFile == nil {
+		return ErrNotInitialized
+	}
+	if err := f.logFile.Sync(); err != nil {
+		return fmt.Errorf("%w: %w", ErrWriteFailed, err)
+	}
+
+	return nil
+}
+
+// WriteTo writes the content of the current log file to dst, implementing io.WriterTo.
+// Entries still queued in logChan are not included
+func (f *FileLogger) WriteTo(dst io.Writer) (n int64, err error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.logFile == nil {
+		return 0, ErrNotInitialized
+	}
+
+	info, err := f.logFile.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrReadFailed, err)
+	}
+
+	// ReadAt leaves the file offset alone, so the appending writer is not disturbed
+	if n, err = io.Copy(dst, io.NewSectionReader(f.logFile, 0, info.Size())); err != nil {
+		return n, fmt.Errorf("%w: %w", ErrReadFailed, err)
+	}
+
+	return n, nil
+}
+
+// WriteAt writes p at offset off of the current log file, implementing io.WriterAt,
+// e.g. to update the status of an entry once an async operation completes.
+// It bypasses logChan, the split check, the offset index and Stats: only use it to overwrite
+// bytes already written, not to append: p ending past the end of the log file is rejected with
+// ErrWriteFailed wrapping io.EOF. Writing a different length than the bytes replaced breaks the lines around them
+func (f *FileLogger) WriteAt(p []byte, off int64) (n int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.logFile == nil {
+		return 0, ErrNotInitialized
+	}
+
+	// the log file is opened with O_APPEND, which WriteAt is not allowed on
+	file, err := os.OpenFile(f.logFile.Name(), os.O_WRONLY, 0)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrWriteFailed, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrWriteFailed, err)
+	}
+	if off+int64(len(p)) > info.Size() {
+		return 0, fmt.Errorf("%w: %w: %v bytes at offset %v, the log file has %v", ErrWriteFailed, io.EOF, len(p), off, info.Size())
+	}
+
+	if n, err = file.WriteAt(p, off); err != nil {
+		return n, fmt.Errorf("%w: %w", ErrWriteFailed, err)
+	}
 
-	return f.logFile.Close()
+	return n, nil
 }
@@ -0,0 +1,140 @@
+package fileLogger
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var benchLine = []byte(fmt.Sprintf("%0127d\n", 0))
+
+// run op b.N times spread over goroutines
+func runGoroutines(b *testing.B, goroutines int, op func()) {
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		n := b.N / goroutines
+		if g < b.N%goroutines {
+			n++
+		}
+
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				op()
+			}
+		}(n)
+	}
+	wg.Wait()
+}
+
+// each op writes a 128 bytes line holding the lock, as p does. p takes the read lock of mu
+func BenchmarkMutexVsRWMutex(b *testing.B) {
+	for _, goroutines := range []int{1, 4, 8, 16} {
+		var mu sync.Mutex
+		var rw sync.RWMutex
+
+		b.Run(fmt.Sprintf("Mutex/%d", goroutines), func(b *testing.B) {
+			runGoroutines(b, goroutines, func() {
+				mu.Lock()
+				io.Discard.Write(benchLine)
+				mu.Unlock()
+			})
+		})
+		b.Run(fmt.Sprintf("RWMutex.Lock/%d", goroutines), func(b *testing.B) {
+			runGoroutines(b, goroutines, func() {
+				rw.Lock()
+				io.Discard.Write(benchLine)
+				rw.Unlock()
+			})
+		})
+		b.Run(fmt.Sprintf("RWMutex.RLock/%d", goroutines), func(b *testing.B) {
+			runGoroutines(b, goroutines, func() {
+				rw.RLock()
+				io.Discard.Write(benchLine)
+				rw.RUnlock()
+			})
+		})
+	}
+}
+
+// drops its writes like io.Discard, which log.Logger recognizes and skips formatting for
+type discardWriter struct{}
+
+func (discardWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+// the candidates of the write path of p for a 128 bytes message, each under the lock as in p:
+// appendLine builds the line into a reused buffer written with a single Write (the one p uses),
+// io.WriteString and fmt.Fprintf format the timestamp apart, log.Logger.Output builds its own header
+func BenchmarkWritePath(b *testing.B) {
+	msg := strings.Repeat("m", 128)
+	var sink io.Writer = discardWriter{}
+	f := &FileLogger{flag: log.LstdFlags | log.Lmicroseconds}
+	const layout = "2006/01/02 15:04:05.000000 "
+
+	for _, goroutines := range []int{1, 8} {
+		var mu sync.Mutex
+		var buf []byte
+		logger := log.New(sink, "", log.LstdFlags|log.Lmicroseconds)
+
+		candidates := []struct {
+			name  string
+			write func()
+		}{
+			{"appendLine", func() {
+				buf = f.appendLine(buf[:0], msg)
+				sink.Write(buf)
+			}},
+			{"io.WriteString", func() {
+				io.WriteString(sink, time.Now().Format(layout)+msg+"\n")
+			}},
+			{"fmt.Fprintf", func() {
+				fmt.Fprintf(sink, "%s%s\n", time.Now().Format(layout), msg)
+			}},
+			{"log.Logger.Output", func() {
+				logger.Output(2, msg)
+			}},
+		}
+
+		for _, c := range candidates {
+			b.Run(fmt.Sprintf("%s/%d", c.name, goroutines), func(b *testing.B) {
+				b.ReportAllocs()
+				runGoroutines(b, goroutines, func() {
+					mu.Lock()
+					c.write()
+					mu.Unlock()
+				})
+			})
+		}
+	}
+}
+
+// each op writes a message with four fields through the text formatter to a log file,
+// allocations of logWriter formatting and writing the line are counted as well
+func BenchmarkWriteFieldsVsWriteKV(b *testing.B) {
+	l := NewPlainLogger(b.TempDir(), "bench.log", "")
+	defer l.Close()
+
+	b.Run("WriteFields", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l.WriteFields(INFO, "user logged in",
+				IntField{"user_id", 42}, StringField{"ip", "127.0.0.1"},
+				BoolField{"admin", false}, Float64Field{"elapsed", 0.25})
+		}
+		l.Flush()
+	})
+	b.Run("WriteKV", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l.WriteKV(INFO, "user logged in",
+				"user_id", 42, "ip", "127.0.0.1",
+				"admin", false, "elapsed", 0.25)
+		}
+		l.Flush()
+	})
+}
@@ -0,0 +1,62 @@
+package fileLogger
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteAt(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+
+	l.I("job 1 status=PENDING")
+	l.I("job 2 status=PENDING")
+	l.Flush()
+
+	path := filepath.Join(dir, "a.log")
+	before := readLog(t, path)
+	off := strings.Index(before, "PENDING")
+	if n, err := l.WriteAt([]byte("SUCCESS"), int64(off)); n != 7 || err != nil {
+		t.Fatalf("WriteAt wrote %d bytes, %v", n, err)
+	}
+
+	after := readLog(t, path)
+	if want := before[:off] + "SUCCESS" + before[off+7:]; after != want {
+		t.Errorf("log file is\n%q\nwant\n%q", after, want)
+	}
+	if strings.Count(after, "status=PENDING") != 1 {
+		t.Errorf("log file is %q, want the second job untouched", after)
+	}
+
+	// the next entry is still appended at the end
+	l.I("job 3")
+	l.Flush()
+	if content := readLog(t, path); !strings.HasPrefix(content, after) || !strings.Contains(content[len(after):], "job 3") {
+		t.Errorf("log file is %q, want job 3 appended", content)
+	}
+}
+
+func TestWriteAtPastEOF(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+
+	l.I("entry")
+	l.Flush()
+
+	path := filepath.Join(dir, "a.log")
+	before := readLog(t, path)
+	for _, off := range []int64{int64(len(before)) - 1, int64(len(before)), int64(len(before)) + 10} {
+		n, err := l.WriteAt([]byte("xx"), off)
+		if n != 0 || !errors.Is(err, ErrWriteFailed) || !errors.Is(err, io.EOF) {
+			t.Errorf("WriteAt at offset %d of %d bytes wrote %d bytes, %v, want ErrWriteFailed wrapping io.EOF", off, len(before), n, err)
+		}
+	}
+	if after := readLog(t, path); after != before {
+		t.Errorf("log file is %q, want it unchanged %q", after, before)
+	}
+}
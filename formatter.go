@@ -0,0 +1,201 @@
+// Package: fileLogger
+// File: formatter.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: formatters turning log entries into text, looked up by name from config files
+// DATE: 26-10-14 06:30
+package fileLogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	RESERVED_FIELD_PREFIX = "fields."
+)
+
+// Formatter turns an entry into the text written after the logger's prefix and timestamp
+type Formatter interface {
+	Format(e *Entry) string
+}
+
+// TextFormatter writes [file:line]message key=value..., it is the default formatter
+type TextFormatter struct{}
+
+func (t *TextFormatter) Format(e *Entry) string {
+	return e.String()
+}
+
+// JSONFormatter writes an entry as a JSON object, use SetFlags(0) and an empty prefix
+// to get one bare JSON object per line. A field named as a member of the entry itself,
+// time, file, line, msg or level, is written as fields.<name>
+type JSONFormatter struct{}
+
+// members of the JSON object of an entry
+var reservedJSONKeys = map[string]bool{"time": true, "file": true, "line": true, "msg": true, "level": true}
+
+// return key, prefixed when it would clash with a member of the entry
+func jsonFieldKey(key string) string {
+	if reservedJSONKeys[key] {
+		return RESERVED_FIELD_PREFIX + key
+	}
+
+	return key
+}
+
+func (j *JSONFormatter) Format(e *Entry) string {
+	obj := make(map[string]interface{}, len(e.Fields)+5)
+	for k, v := range e.Fields {
+		// a value json can not marshal must not lose the whole entry
+		if _, err := json.Marshal(v); err != nil {
+			v = fmt.Sprintf("%v", v)
+		}
+		obj[jsonFieldKey(k)] = v
+	}
+	obj["time"] = e.Time
+	obj["file"] = e.File
+	obj["line"] = e.Line
+	obj["msg"] = e.Msg
+	if e.Level < OFF {
+		obj["level"] = e.Level.String()
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return e.String()
+	}
+
+	return string(e.appendTypedJSON(b))
+}
+
+var formatters sync.Map
+
+func init() {
+	RegisterFormatter("text", &TextFormatter{})
+	RegisterFormatter("json", &JSONFormatter{})
+}
+
+// RegisterFormatter makes a formatter available by name, a formatter registered twice replaces the first one
+func RegisterFormatter(name string, f Formatter) {
+	formatters.Store(name, f)
+}
+
+// GetFormatter returns the formatter registered by name
+func GetFormatter(name string) (Formatter, bool) {
+	f, ok := formatters.Load(name)
+	if !ok {
+		return nil, false
+	}
+
+	return f.(Formatter), true
+}
+
+// format e with f's formatter
+func (f *FileLogger) format(e *Entry) string {
+	f.mu.RLock()
+	formatter := f.formatter
+	f.mu.RUnlock()
+
+	if formatter == nil {
+		return e.String()
+	}
+
+	return formatter.Format(e)
+}
+
+// Parser is implemented by formatters able to read back the entries they format
+type Parser interface {
+	Parse(line string) (*Entry, error)
+}
+
+var (
+	// [prefix][date ][time ][file:line]rest
+	textLineRegexp = regexp.MustCompile(`^.*?(\d{4}/\d{2}/\d{2} )?(\d{2}:\d{2}:\d{2}(?:\.\d+)? )?\[([^\[\]:]+):(\d+)\](.*)$`)
+	// colored [LEVEL] tag of a leveled entry, the first line of a multiline message has no color reset
+	textLevelRegexp = regexp.MustCompile(`^\033\[[0-9;]*m\[(\w+)\] (.*?)(?: \033\[0m (.*))?$`)
+)
+
+// Parse reads back a line written with TextFormatter, the logger's prefix is skipped.
+// Fields are only recovered for leveled entries, and only up to their first space
+func (t *TextFormatter) Parse(line string) (*Entry, error) {
+	m := textLineRegexp.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+	if m == nil {
+		return nil, fmt.Errorf("%w: %q", ErrMalformedLine, line)
+	}
+
+	e := &Entry{Level: OFF, File: m[3], Msg: m[5]}
+	e.Line, _ = strconv.Atoi(m[4])
+	if stamp := strings.TrimSpace(m[1] + m[2]); stamp != "" {
+		for _, layout := range []string{"2006/01/02 15:04:05.000000", "2006/01/02 15:04:05", "2006/01/02", "15:04:05.000000", "15:04:05"} {
+			if t, err := time.ParseInLocation(layout, stamp, time.Local); err == nil {
+				e.Time = t
+				break
+			}
+		}
+	}
+
+	if lm := textLevelRegexp.FindStringSubmatch(e.Msg); lm != nil {
+		if level, ok := parseLevel(lm[1]); ok {
+			e.Level = level
+			e.Msg = lm[2]
+			for _, kv := range strings.Fields(lm[3]) {
+				if i := strings.Index(kv, "="); i > 0 {
+					e.setField(kv[:i], kv[i+1:])
+				}
+			}
+		}
+	}
+
+	return e, nil
+}
+
+// Parse reads back a line written with JSONFormatter
+func (j *JSONFormatter) Parse(line string) (*Entry, error) {
+	obj := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(line), &obj); err != nil {
+		return nil, err
+	}
+
+	e := &Entry{Level: OFF}
+	for k, v := range obj {
+		switch k {
+		case "time":
+			if s, ok := v.(string); ok {
+				e.Time, _ = time.Parse(time.RFC3339Nano, s)
+			}
+		case "file":
+			e.File, _ = v.(string)
+		case "line":
+			if n, ok := v.(float64); ok {
+				e.Line = int(n)
+			}
+		case "msg":
+			e.Msg, _ = v.(string)
+		case "level":
+			if s, ok := v.(string); ok {
+				e.Level, _ = parseLevel(s)
+			}
+		default:
+			if key := strings.TrimPrefix(k, RESERVED_FIELD_PREFIX); reservedJSONKeys[key] {
+				k = key
+			}
+			e.setField(k, v)
+		}
+	}
+
+	return e, nil
+}
+
+// ParseLine reads back a line written by either built-in formatter, telling JSON from text by its first character
+func ParseLine(line string) (*Entry, error) {
+	if strings.HasPrefix(strings.TrimSpace(line), "{") {
+		return (&JSONFormatter{}).Parse(line)
+	}
+
+	return (&TextFormatter{}).Parse(line)
+}
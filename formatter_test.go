@@ -0,0 +1,89 @@
+package fileLogger
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTextFormatter(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry *Entry
+		want  string
+	}{
+		{"no fields", &Entry{Level: INFO, File: "a.go", Line: 1, Msg: "msg"},
+			"[a.go:1]" + levelColors[INFO] + "[INFO] msg \033[0m "},
+		{"fields", &Entry{Level: INFO, File: "a.go", Line: 1, Msg: "msg", Fields: map[string]interface{}{"b": 2, "a": "x"}},
+			"[a.go:1]" + levelColors[INFO] + "[INFO] msg \033[0m a=x b=2"},
+		{"typed fields", &Entry{Level: WARN, File: "a.go", Line: 1, Msg: "msg", typed: []Field{IntField{"n", 1}, StringField{"s", "y"}}},
+			"[a.go:1]" + levelColors[WARN] + "[WARN] msg \033[0m n=1 s=y"},
+		{"both", &Entry{Level: ERROR, File: "a.go", Line: 1, Msg: "msg", Fields: map[string]interface{}{"a": 1}, typed: []Field{IntField{"n", 1}}},
+			"[a.go:1]" + levelColors[ERROR] + "[ERROR] msg \033[0m a=1 n=1"},
+		{"print", &Entry{Level: OFF, File: "a.go", Line: 1, Msg: "msg", Fields: map[string]interface{}{"a": 1}},
+			"[a.go:1]msg a=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&TextFormatter{}).Format(tt.entry); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextFormatterParse(t *testing.T) {
+	e := &Entry{Level: INFO, File: "a.go", Line: 7, Msg: "msg", Fields: map[string]interface{}{"a": "x"}}
+	parsed, err := (&TextFormatter{}).Parse("2026/10/14 08:00:00 " + (&TextFormatter{}).Format(e))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Level != INFO || parsed.File != "a.go" || parsed.Line != 7 || parsed.Msg != "msg" || parsed.Fields["a"] != "x" {
+		t.Errorf("Parse() = %+v, want the formatted entry back", parsed)
+	}
+}
+
+// a field named as a member of the entry is written as fields.<name>, whether in Fields or typed
+func TestJSONFormatterReservedKeys(t *testing.T) {
+	e := &Entry{
+		Level:  INFO,
+		Time:   time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC),
+		File:   "a.go",
+		Line:   1,
+		Msg:    "msg",
+		Fields: map[string]interface{}{"msg": "field", "file": "f"},
+		typed:  []Field{StringField{"level", "typed"}, IntField{"line", 2}, IntField{"n", 3}},
+	}
+	out := (&JSONFormatter{}).Format(e)
+
+	// a duplicate key would be silently overwritten by Unmarshal, count them in the raw output
+	for _, key := range []string{"msg", "file", "level", "line"} {
+		if n := strings.Count(out, `"`+key+`":`); n != 1 {
+			t.Errorf("%v has %d %q members, want 1", out, n, key)
+		}
+	}
+
+	obj := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(out), &obj); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"msg": "msg", "file": "a.go", "level": "INFO", "line": float64(1), "n": float64(3),
+		"fields.msg": "field", "fields.file": "f", "fields.level": "typed", "fields.line": float64(2),
+	}
+	for key, value := range want {
+		if obj[key] != value {
+			t.Errorf("%v = %v, want %v", key, obj[key], value)
+		}
+	}
+
+	parsed, err := (&JSONFormatter{}).Parse(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Msg != "msg" || parsed.Fields["msg"] != "field" || parsed.Fields["level"] != "typed" {
+		t.Errorf("Parse() = %+v, want the reserved fields back under their name", parsed)
+	}
+}
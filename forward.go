@@ -0,0 +1,127 @@
+// Package: fileLogger
+// File: forward.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: replay an existing log file to a sink and keep following it
+// DATE: 26-10-14 07:40
+package fileLogger
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+const (
+	DEFAULT_FORWARD_POLL = 200 * time.Millisecond
+)
+
+// Sink receives the entries forwarded by ForwardFile
+type Sink interface {
+	Write(e *Entry) error
+}
+
+// ForwardFile sends every line of srcPath to sink, then follows srcPath sending new lines as they are
+// written, until ctx is done. Lines are parsed by the built-in formatters, a line they can not parse
+// is forwarded as the Msg of an OFF entry. A truncated or rotated srcPath is reopened from its start
+func ForwardFile(ctx context.Context, srcPath string, sink Sink) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		src.Close()
+	}()
+
+	var offset int64
+	partial := ""
+	reader := bufio.NewReader(src)
+	ticker := time.NewTicker(DEFAULT_FORWARD_POLL)
+	defer ticker.Stop()
+
+	// send the complete lines available in src, keeping a trailing incomplete line in partial
+	drain := func() error {
+		for {
+			line, err := reader.ReadString('\n')
+			offset += int64(len(line))
+			partial += line
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			if err := sink.Write(forwardEntry(partial)); err != nil {
+				return err
+			}
+			partial = ""
+		}
+	}
+
+	for {
+		if err := drain(); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		reopen, err := mustReopen(src, srcPath, offset)
+		if err != nil {
+			return err
+		}
+		if !reopen {
+			continue
+		}
+
+		// whatever was written before the rotation still belongs to the old file
+		if err := drain(); err != nil {
+			return err
+		}
+		if partial != "" {
+			if err := sink.Write(forwardEntry(partial)); err != nil {
+				return err
+			}
+		}
+
+		src.Close()
+		if src, err = os.Open(srcPath); err != nil {
+			return err
+		}
+		offset = 0
+		partial = ""
+		reader.Reset(src)
+	}
+}
+
+// parse a forwarded line, falling back to the raw line as message
+func forwardEntry(line string) *Entry {
+	e, err := ParseLine(line)
+	if err != nil {
+		return &Entry{Level: OFF, Time: time.Now(), Msg: line}
+	}
+
+	return e
+}
+
+// mustReopen reports whether srcPath has been truncated below offset or replaced by another file
+func mustReopen(src *os.File, srcPath string, offset int64) (bool, error) {
+	current, err := os.Stat(srcPath)
+	if os.IsNotExist(err) {
+		// rotated away and not created again yet
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	opened, err := src.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	return !os.SameFile(current, opened) || current.Size() < offset, nil
+}
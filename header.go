@@ -0,0 +1,43 @@
+// Package: fileLogger
+// File: header.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: headers written before each formatted entry, such as [AUDIT]
+// DATE: 26-10-14 12:40
+package fileLogger
+
+// EntryHeader returns the text written before the formatted entry e,
+// after the logger's prefix and timestamp. A header panicking on an entry writes nothing for it
+type EntryHeader func(e Entry) string
+
+// AddEntryHeader adds h to the headers of f, headers are written one after another in the order they are added.
+// Lines of JSONFormatter with a header are no longer read back by ParseLine
+func (f *FileLogger) AddEntryHeader(h EntryHeader) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.entryHeaders = append(f.entryHeaders, h)
+}
+
+// RequestEntryHeader returns a header writing "[REQUEST] "
+func RequestEntryHeader() EntryHeader {
+	return func(Entry) string { return "[REQUEST] " }
+}
+
+// AuditEntryHeader returns a header writing "[AUDIT] "
+func AuditEntryHeader() EntryHeader {
+	return func(Entry) string { return "[AUDIT] " }
+}
+
+// return the headers of e
+func (f *FileLogger) header(e *Entry) string {
+	f.mu.RLock()
+	headers := f.entryHeaders
+	f.mu.RUnlock()
+
+	str := ""
+	for _, h := range headers {
+		f.safely("entry header", func() { str += h(*e) })
+	}
+
+	return str
+}
@@ -0,0 +1,31 @@
+package fileLogger
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEntryHeader(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.AddEntryHeader(AuditEntryHeader())
+	l.AddEntryHeader(func(e Entry) string {
+		if e.Msg == "boom" {
+			panic("header failed")
+		}
+		return "[OK] "
+	})
+
+	l.I("boom")
+	l.I("after")
+	l.Flush()
+
+	content := readLog(t, filepath.Join(dir, "a.log"))
+	for _, want := range []string{"FileLogger entry header panic: header failed", "[AUDIT] [header_test.go", "[AUDIT] [OK] [header_test.go"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("a.log = %q, want it to hold %q", content, want)
+		}
+	}
+}
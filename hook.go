@@ -0,0 +1,51 @@
+// Package: fileLogger
+// File: hook.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: hooks run on each entry before it is formatted
+// DATE: 26-10-14 08:20
+package fileLogger
+
+// TransformHook may add fields to an entry, change its level or its message, Fields is never nil.
+// An entry whose level ends up below the log level is dropped, so is an entry a hook panics on
+type TransformHook func(entry Entry) Entry
+
+// run e through f's transform hooks in order, false when the lock timed out or a hook panicked.
+// The level e ends up with is checked by levelAllowed
+func (f *FileLogger) transform(e *Entry) bool {
+	if !f.rlock() {
+		return false
+	}
+	hooks := f.transformHooks
+	f.mu.RUnlock()
+
+	if len(hooks) == 0 {
+		return true
+	}
+
+	// hooks can add fields straight away, the map is kept by the entry pool
+	if e.Fields == nil {
+		e.Fields = make(map[string]interface{})
+	}
+	for _, hook := range hooks {
+		if !f.safely("transform hook", func() { *e = hook(*e) }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// run fn, a callback set by the user, in logWriter: a panic of fn is written to the log file
+// instead of stopping logWriter. Reports whether fn returned
+func (f *FileLogger) safely(name string, fn func()) (ok bool) {
+	defer func() {
+		if err := recover(); err != nil {
+			f.mu.RLock()
+			f.printf("FileLogger %v panic: %v", name, err)
+			f.mu.RUnlock()
+		}
+	}()
+
+	fn()
+	return true
+}
@@ -0,0 +1,86 @@
+package fileLogger
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTransformHook(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.AddTransformHook(func(e Entry) Entry {
+		e.Fields["host"] = "web1"
+		if strings.HasPrefix(e.Msg, "debug") {
+			e.Level = TRACE
+		}
+		return e
+	})
+	l.SetLogLevel(INFO)
+
+	l.I("kept")
+	l.I("debug dropped")
+	l.Flush()
+
+	content := readLog(t, filepath.Join(dir, "a.log"))
+	if !strings.Contains(content, "kept") || !strings.Contains(content, "host=web1") {
+		t.Errorf("a.log = %q, want the kept entry with the hook's field", content)
+	}
+	if strings.Contains(content, "debug dropped") {
+		t.Errorf("a.log = %q, want the entry lowered below the log level dropped", content)
+	}
+}
+
+// a panicking hook drops its entry, logWriter goes on with the next ones
+func TestTransformHookPanic(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.AddTransformHook(func(e Entry) Entry {
+		if e.Msg == "boom" {
+			panic("hook failed")
+		}
+		return e
+	})
+
+	l.I("boom")
+	l.I("after")
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	content := readLog(t, filepath.Join(dir, "a.log"))
+	if !strings.Contains(content, "FileLogger transform hook panic: hook failed") || !strings.Contains(content, "after") {
+		t.Errorf("a.log = %q, want the panic reported and the next entry written", content)
+	}
+	if strings.Contains(content, "] boom") {
+		t.Errorf("a.log = %q, want the entry the hook panicked on dropped", content)
+	}
+}
+
+type panicFormatter struct{}
+
+func (panicFormatter) Format(e *Entry) string {
+	if e.Msg == "boom" {
+		panic("format failed")
+	}
+	return e.Msg
+}
+
+func TestFormatterPanic(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.SetFormatter(panicFormatter{})
+
+	l.I("boom")
+	l.I("after")
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if content := readLog(t, filepath.Join(dir, "a.log")); !strings.Contains(content, "FileLogger formatter panic: format failed") || !strings.Contains(content, "after") {
+		t.Errorf("a.log = %q, want the panic reported and the next entry written", content)
+	}
+}
@@ -0,0 +1,108 @@
+// Package: fileLogger
+// File: hourly.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: concatenate the hourly files of a day into one daily file
+// DATE: 26-10-14 13:20
+package fileLogger
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AggregateHourlyToDaily concatenates the hourly files of date in fileDir, named baseName*.<date>_<hour>,
+// e.g. app.log.2024-01-02_13, into fileDir/baseName.<date> in the order of their hour and removes them.
+// fileLogger itself does not split hourly, the files come from outside rotation tools.
+// Hourly files ending in .gz are decompressed, the daily file is gzip compressed with a .gz suffix
+// when any of them was. The daily file is written aside and renamed once complete,
+// the hourly files are only removed after that
+func AggregateHourlyToDaily(fileDir, baseName string, date time.Time) (dstPath string, err error) {
+	day := date.Format(DATEFORMAT)
+	paths, err := filepath.Glob(joinFilePath(fileDir, baseName+"*."+day+"_*"))
+	if err != nil {
+		return "", err
+	}
+	if len(paths) == 0 {
+		return "", fmt.Errorf("%w: no hourly files of %v on %v in %v", os.ErrNotExist, baseName, day, fileDir)
+	}
+
+	// the hour follows the last "<date>_", zero padded hours sort by name
+	hour := func(path string) string {
+		path = strings.TrimSuffix(path, ".gz")
+		return path[strings.LastIndex(path, day+"_")+len(day)+1:]
+	}
+	sort.Slice(paths, func(i, j int) bool { return hour(paths[i]) < hour(paths[j]) })
+
+	dstPath = joinFilePath(fileDir, baseName+"."+day)
+	for _, path := range paths {
+		if strings.HasSuffix(path, ".gz") {
+			dstPath += ".gz"
+			break
+		}
+	}
+
+	if err := concatLogs(dstPath+".tmp", paths); err != nil {
+		os.Remove(dstPath + ".tmp")
+		return "", err
+	}
+	if err := os.Rename(dstPath+".tmp", dstPath); err != nil {
+		os.Remove(dstPath + ".tmp")
+		return "", err
+	}
+
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			return dstPath, err
+		}
+	}
+
+	return dstPath, nil
+}
+
+// write the content of paths in order to dstPath, compressed when dstPath is a .gz once the .tmp is trimmed
+func concatLogs(dstPath string, paths []string) (err error) {
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := dst.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	var gz *gzip.Writer
+	w := bufio.NewWriter(dst)
+	if strings.HasSuffix(strings.TrimSuffix(dstPath, ".tmp"), ".gz") {
+		gz = gzip.NewWriter(dst)
+		w = bufio.NewWriter(gz)
+	}
+
+	for _, path := range paths {
+		src, err := openLog(path)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, src)
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if gz != nil {
+		return gz.Close()
+	}
+
+	return nil
+}
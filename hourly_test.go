@@ -0,0 +1,91 @@
+package fileLogger
+
+import (
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAggregateHourlyToDaily(t *testing.T) {
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)
+
+	for _, compressed := range []bool{false, true} {
+		name := "plain"
+		if compressed {
+			name = "gzip"
+		}
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			hourly := make([]string, 0, 3)
+			// written out of order, the hours decide the order
+			for i, hour := range []string{"13", "02", "09"} {
+				path := filepath.Join(dir, "app.log.2024-01-02_"+hour)
+				content := "entry at " + hour + "\n"
+				if compressed && i == 0 {
+					path += ".gz"
+					writeGzip(t, path, content)
+				} else if err := os.WriteFile(path, []byte(content), 0666); err != nil {
+					t.Fatal(err)
+				}
+				hourly = append(hourly, path)
+			}
+			other := filepath.Join(dir, "app.log.2024-01-03_00")
+			os.WriteFile(other, []byte("next day\n"), 0666)
+
+			dstPath, err := AggregateHourlyToDaily(dir, "app.log", date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := filepath.Join(dir, "app.log.2024-01-02")
+			if compressed {
+				want += ".gz"
+			}
+			if dstPath != want {
+				t.Errorf("daily file is %v, want %v", dstPath, want)
+			}
+
+			if content := readLogFile(t, dstPath); content != "entry at 02\nentry at 09\nentry at 13\n" {
+				t.Errorf("daily file holds %q, want the entries in the order of their hour", content)
+			}
+			for _, path := range hourly {
+				if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("hourly file %v not removed: %v", path, err)
+				}
+			}
+			if _, err := os.Stat(other); err != nil {
+				t.Errorf("hourly file of the next day removed: %v", err)
+			}
+			if tmp, _ := filepath.Glob(filepath.Join(dir, "*.tmp")); len(tmp) != 0 {
+				t.Errorf("temporary files left: %v", tmp)
+			}
+		})
+	}
+}
+
+func TestAggregateHourlyToDailyNoFiles(t *testing.T) {
+	_, err := AggregateHourlyToDaily(t.TempDir(), "app.log", time.Now())
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("error %v, want os.ErrNotExist", err)
+	}
+}
+
+func writeGzip(t *testing.T, path, content string) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	if _, err := gz.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,114 @@
+// Package: fileLogger
+// File: http.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: log http requests as structured entries
+// DATE: 26-10-14 10:10
+package fileLogger
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	REDACTED = "REDACTED"
+)
+
+// headers written as REDACTED until SetSensitiveHeaders is called
+var defaultSensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// WriteHTTP writes req at level as the fields method, url, remote_addr, content_type, content_length,
+// user_agent and headers, followed by extraFields. The values of the headers set by SetSensitiveHeaders
+// are REDACTED, so is the password of the url. The body of req is not read
+func (f *FileLogger) WriteHTTP(level LEVEL, req *http.Request, extraFields ...Field) error {
+	_, file, line, _ := runtime.Caller(1) //calldepth=2
+	if !f.enabled(level) {
+		return nil
+	}
+	if req == nil || req.URL == nil {
+		return fmt.Errorf("%w: request or its url is nil", ErrMissingField)
+	}
+
+	sensitive := f.sensitive()
+	reqURL := req.URL.Redacted()
+	e := f.entry(level, file, line, req.Method+" "+reqURL, nil)
+	e.setField("method", req.Method)
+	e.setField("url", reqURL)
+	e.setField("remote_addr", req.RemoteAddr)
+	e.setField("content_type", headerField(req.Header, "Content-Type", sensitive))
+	e.setField("content_length", req.ContentLength)
+	e.setField("user_agent", headerField(req.Header, "User-Agent", sensitive))
+	e.setField("headers", headerFields(req.Header, sensitive))
+	e.typed = append(e.typed, extraFields...)
+
+	return f.send(e)
+}
+
+// WriteResponse writes resp at level as the fields status_code, content_type, content_length, duration_ms,
+// request_id and headers, followed by extraFields. Headers are redacted as by WriteHTTP, requestID pairs
+// the entry with the request's. The body of resp is not read
+func (f *FileLogger) WriteResponse(level LEVEL, resp *http.Response, requestID string, duration time.Duration,
+	extraFields ...Field) error {
+	_, file, line, _ := runtime.Caller(1) //calldepth=2
+	if !f.enabled(level) {
+		return nil
+	}
+	if resp == nil {
+		return fmt.Errorf("%w: response is nil", ErrMissingField)
+	}
+
+	sensitive := f.sensitive()
+	e := f.entry(level, file, line, resp.Status, nil)
+	e.setField("status_code", resp.StatusCode)
+	e.setField("content_type", headerField(resp.Header, "Content-Type", sensitive))
+	e.setField("content_length", resp.ContentLength)
+	e.setField("duration_ms", duration.Milliseconds())
+	e.setField("request_id", requestID)
+	e.setField("headers", headerFields(resp.Header, sensitive))
+	e.typed = append(e.typed, extraFields...)
+
+	return f.send(e)
+}
+
+// return the canonical names of the headers to redact
+func (f *FileLogger) sensitive() map[string]bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.sensitiveHeaders == nil {
+		return defaultSensitiveHeaders
+	}
+
+	return f.sensitiveHeaders
+}
+
+// return the first value of the header key, redacted when it is sensitive
+func headerField(header http.Header, key string, sensitive map[string]bool) string {
+	if sensitive[key] && header.Get(key) != "" {
+		return REDACTED
+	}
+
+	return header.Get(key)
+}
+
+// return header as a map of comma separated values with the sensitive ones redacted
+func headerFields(header http.Header, sensitive map[string]bool) map[string]string {
+	fields := make(map[string]string, len(header))
+	for k, v := range header {
+		if sensitive[http.CanonicalHeaderKey(k)] {
+			fields[k] = REDACTED
+		} else {
+			fields[k] = strings.Join(v, ", ")
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,138 @@
+// Package: fileLogger
+// File: index.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: offsets of the log lines in the current log file, to seek to a line by its number
+// DATE: 26-10-14 09:00
+package fileLogger
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+const (
+	OFFSET_INDEX_SUFFIX = ".oidx"
+)
+
+// path of the sidecar file keeping the offset index across restarts
+func (f *FileLogger) indexFile() string {
+	return joinFilePath(f.fileDir, f.fileName) + OFFSET_INDEX_SUFFIX
+}
+
+// record the offset of the line about to be written, called by p() with f.mu held
+func (f *FileLogger) indexLine() {
+	offset, err := f.logFile.Seek(0, io.SeekEnd)
+	if err != nil {
+		return
+	}
+
+	f.indexMu.Lock()
+	f.index = append(f.index, offset)
+	f.indexMu.Unlock()
+}
+
+// forget the offsets of the log file just split away
+func (f *FileLogger) resetIndex() {
+	f.indexMu.Lock()
+	f.index = f.index[:0]
+	f.indexMu.Unlock()
+
+	os.Remove(f.indexFile())
+}
+
+// load the offset index of the current log file from its sidecar file,
+// rebuilding it from the log file when the sidecar is missing or does not match
+func (f *FileLogger) loadIndex() {
+	logFile := joinFilePath(f.fileDir, f.fileName)
+	size := fileSize(logFile)
+
+	var index []int64
+	if b, err := ioutil.ReadFile(f.indexFile()); err == nil && len(b)%8 == 0 {
+		index = make([]int64, len(b)/8)
+		for i := range index {
+			index[i] = int64(binary.LittleEndian.Uint64(b[i*8:]))
+		}
+	}
+
+	if len(index) > 0 && index[len(index)-1] >= size {
+		index = nil
+	}
+	if index == nil {
+		index = scanIndex(logFile)
+	}
+
+	f.indexMu.Lock()
+	f.index = index
+	f.indexMu.Unlock()
+}
+
+// offsets of the lines of file
+func scanIndex(file string) []int64 {
+	index := []int64{}
+
+	src, err := os.Open(file)
+	if err != nil {
+		return index
+	}
+	defer src.Close()
+
+	var offset int64
+	reader := bufio.NewReader(src)
+	for {
+		line, err := reader.ReadSlice('\n')
+		if len(line) > 0 && (err == nil || err == bufio.ErrBufferFull) {
+			index = append(index, offset)
+		}
+		for err == bufio.ErrBufferFull {
+			offset += int64(len(line))
+			line, err = reader.ReadSlice('\n')
+		}
+		offset += int64(len(line))
+		if err != nil {
+			return index
+		}
+	}
+}
+
+// write the offset index to its sidecar file
+func (f *FileLogger) saveIndex() error {
+	f.indexMu.Lock()
+	b := make([]byte, len(f.index)*8)
+	for i, offset := range f.index {
+		binary.LittleEndian.PutUint64(b[i*8:], uint64(offset))
+	}
+	f.indexMu.Unlock()
+
+	return ioutil.WriteFile(f.indexFile(), b, 0666)
+}
+
+// SeekToEntry returns a reader of the current log file starting at its line n, counted from 0.
+// It needs SetOffsetIndex(true), lines written before the last split are not in the index
+func (f *FileLogger) SeekToEntry(n int64) (io.ReadCloser, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	f.indexMu.Lock()
+	if n < 0 || n >= int64(len(f.index)) {
+		f.indexMu.Unlock()
+		return nil, fmt.Errorf("%w: entry %v of %v", ErrEntryNotIndexed, n, len(f.index))
+	}
+	offset := f.index[n]
+	f.indexMu.Unlock()
+
+	src, err := os.Open(joinFilePath(f.fileDir, f.fileName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReadFailed, err)
+	}
+
+	if _, err := src.Seek(offset, io.SeekStart); err != nil {
+		src.Close()
+		return nil, fmt.Errorf("%w: %w", ErrReadFailed, err)
+	}
+
+	return src, nil
+}
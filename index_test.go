@@ -0,0 +1,93 @@
+package fileLogger
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+// write lines from..to-1 to a logger of dir with the offset index on, and close it
+func writeIndexed(t *testing.T, dir string, from, to int) {
+	l := NewPlainLogger(dir, "a.log", "")
+	l.SetOffsetIndex(true)
+	for n := from; n < to; n++ {
+		l.I("line %d", n)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// check that the line read at entry n of l is line n
+func checkSeek(t *testing.T, l *FileLogger, n int64) {
+	t.Helper()
+	r, err := l.SeekToEntry(n)
+	if err != nil {
+		t.Fatalf("seek to entry %d: %v", n, err)
+	}
+	defer r.Close()
+
+	line, _ := bufio.NewReader(r).ReadString('\n')
+	if !strings.Contains(line, fmt.Sprintf("] line %d ", n)) {
+		t.Errorf("entry %d starts at %q", n, line)
+	}
+}
+
+func TestSeekToEntryAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	writeIndexed(t, dir, 0, 5)
+
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	b, err := ioutil.ReadFile(l.indexFile())
+	if err != nil || len(b) != 5*8 {
+		t.Fatalf("sidecar file holds %d bytes, %v, want 5 offsets", len(b), err)
+	}
+
+	l.SetOffsetIndex(true)
+	for n := int64(0); n < 5; n++ {
+		checkSeek(t, l, n)
+	}
+
+	l.I("line 5")
+	l.Flush()
+	checkSeek(t, l, 5)
+
+	if _, err := l.SeekToEntry(6); !errors.Is(err, ErrEntryNotIndexed) {
+		t.Errorf("seek past the last entry: %v, want ErrEntryNotIndexed", err)
+	}
+}
+
+func TestSeekToEntryRebuilt(t *testing.T) {
+	for name, damage := range map[string]func(string){
+		"missing": func(file string) { os.Remove(file) },
+		"stale": func(file string) {
+			b := make([]byte, 8)
+			binary.LittleEndian.PutUint64(b, 1<<20)
+			ioutil.WriteFile(file, b, 0666)
+		},
+		"corrupt": func(file string) { ioutil.WriteFile(file, []byte("garbage"), 0666) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeIndexed(t, dir, 0, 5)
+
+			l := NewPlainLogger(dir, "a.log", "")
+			defer l.Close()
+			damage(l.indexFile())
+
+			l.SetOffsetIndex(true)
+			for n := int64(0); n < 5; n++ {
+				checkSeek(t, l, n)
+			}
+			if _, err := l.SeekToEntry(5); !errors.Is(err, ErrEntryNotIndexed) {
+				t.Errorf("seek past the last entry: %v, want ErrEntryNotIndexed", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Package: fileLogger
+// File: level.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: notifications of log level changes
+// DATE: 26-10-14 07:20
+package fileLogger
+
+import (
+	"time"
+)
+
+// LevelChangeEvent describes one SetLogLevel call
+type LevelChangeEvent struct {
+	OldLevel  LEVEL
+	NewLevel  LEVEL
+	ChangedAt time.Time
+}
+
+// LevelChangeChan returns a channel receiving an event for each SetLogLevel call and a func to stop it.
+// Events are dropped while the channel is full, the stop func closes the channel
+func (f *FileLogger) LevelChangeChan(capacity int) (<-chan LevelChangeEvent, func()) {
+	ch := make(chan LevelChangeEvent, capacity)
+
+	f.levelMu.Lock()
+	if f.levelChans == nil {
+		f.levelChans = make(map[chan LevelChangeEvent]struct{})
+	}
+	f.levelChans[ch] = struct{}{}
+	f.levelMu.Unlock()
+
+	stop := func() {
+		f.levelMu.Lock()
+		defer f.levelMu.Unlock()
+
+		if _, ok := f.levelChans[ch]; ok {
+			delete(f.levelChans, ch)
+			close(ch)
+		}
+	}
+
+	return ch, stop
+}
@@ -0,0 +1,51 @@
+// Package: fileLogger
+// File: lock.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: bound the time log writes wait for f.mu
+// DATE: 26-10-14 10:30
+package fileLogger
+
+import (
+	"time"
+)
+
+const (
+	LOCK_RETRY_MIN = 10 * time.Microsecond
+	LOCK_RETRY_MAX = time.Millisecond
+)
+
+// SetLockTimeout sets how long a log write waits for the lock held while the log file is split or reopened,
+// e.g. during a slow rotation on NFS, default is 0 which waits as long as it takes.
+// A write timing out is dropped and counted in Stats().LockTimeoutCount, the methods returning an error
+// return ErrLockTimeout
+func (f *FileLogger) SetLockTimeout(d time.Duration) {
+	f.lockTimeout.Store(int64(d))
+}
+
+// read lock f.mu, giving up after the lock timeout. Retries back off from LOCK_RETRY_MIN to LOCK_RETRY_MAX
+func (f *FileLogger) rlock() bool {
+	timeout := time.Duration(f.lockTimeout.Load())
+	if timeout <= 0 {
+		f.mu.RLock()
+		return true
+	}
+
+	deadline := time.Now().Add(timeout)
+	for retry := LOCK_RETRY_MIN; !f.mu.TryRLock(); retry *= 2 {
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			f.lockTimeoutCount.Add(1)
+			return false
+		}
+
+		if retry > LOCK_RETRY_MAX {
+			retry = LOCK_RETRY_MAX
+		}
+		if retry > wait {
+			retry = wait
+		}
+		time.Sleep(retry)
+	}
+
+	return true
+}
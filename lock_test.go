@@ -0,0 +1,94 @@
+package fileLogger
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// hold the write lock of f.mu for d, as a slow split does
+func holdLock(f *FileLogger, d time.Duration) {
+	locked := make(chan struct{})
+	go func() {
+		f.mu.Lock()
+		close(locked)
+		time.Sleep(d)
+		f.mu.Unlock()
+	}()
+	<-locked
+}
+
+func TestLockTimeout(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+	l.SetLockTimeout(100 * time.Millisecond)
+
+	holdLock(l, 200*time.Millisecond)
+	start := time.Now()
+	err := l.WriteString(INFO, "entry")
+	if elapsed := time.Since(start); elapsed >= 150*time.Millisecond {
+		t.Errorf("WriteString() took %v, want it to give up after the 100ms lock timeout", elapsed)
+	}
+	if !errors.Is(err, ErrLockTimeout) {
+		t.Errorf("WriteString() = %v, want ErrLockTimeout", err)
+	}
+	if n := l.Stats().LockTimeoutCount; n != 1 {
+		t.Errorf("LockTimeoutCount = %d, want 1", n)
+	}
+}
+
+// the log methods do not return an error, logWriter drops their entry once the lock times out
+func TestLockTimeoutDropsEntry(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.SetLockTimeout(100 * time.Millisecond)
+
+	holdLock(l, 200*time.Millisecond)
+	l.I("dropped")
+	l.Flush()
+	l.I("written")
+	l.Flush()
+
+	content := readLog(t, filepath.Join(dir, "a.log"))
+	if strings.Contains(content, "dropped") || !strings.Contains(content, "written") {
+		t.Errorf("a.log = %q, want only the entry logged once the lock was released", content)
+	}
+	if n := l.Stats().LockTimeoutCount; n != 1 {
+		t.Errorf("LockTimeoutCount = %d, want 1", n)
+	}
+}
+
+// without a lock timeout a write waits for the lock as long as it takes
+func TestNoLockTimeoutWaits(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	holdLock(l, 50*time.Millisecond)
+	if err := l.WriteString(INFO, "entry"); err != nil {
+		t.Errorf("WriteString() = %v, want nil once the lock is released", err)
+	}
+}
+
+// reading the package overrides gives up after the lock timeout as the write does
+func TestLockTimeoutPackageOverride(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+	l.SetPackageAnnotation(true)
+	l.SetPackageLevelOverride("testing", INFO)
+	l.SetLockTimeout(100 * time.Millisecond)
+
+	holdLock(l, 200*time.Millisecond)
+	start := time.Now()
+	if l.levelAllowed(l.entry(INFO, "lock_test.go", 1, "entry", nil)) {
+		t.Error("levelAllowed() = true, want the entry dropped on the lock timeout")
+	}
+	if elapsed := time.Since(start); elapsed >= 150*time.Millisecond {
+		t.Errorf("levelAllowed() took %v, want it to give up after the 100ms lock timeout", elapsed)
+	}
+	if n := l.Stats().LockTimeoutCount; n != 1 {
+		t.Errorf("LockTimeoutCount = %d, want 1", n)
+	}
+}
@@ -0,0 +1,95 @@
+// Package: fileLogger
+// File: memory.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: keep the last written entries in memory, e.g. for a debug page
+// DATE: 26-10-14 11:30
+package fileLogger
+
+import (
+	"sync"
+	"time"
+)
+
+// ringBuffer holds the last written entries, times holds their Time to check their age without copying them
+type ringBuffer struct {
+	mu      sync.Mutex
+	entries []Entry
+	times   []time.Time
+	next    int
+	count   int
+	maxAge  time.Duration
+}
+
+// SetMemoryBuffer keeps the last size written entries in memory for Last, default is 0 which keeps none.
+// The entries already kept are dropped
+func (f *FileLogger) SetMemoryBuffer(size int) {
+	if size < 0 {
+		size = 0
+	}
+
+	f.memory.mu.Lock()
+	defer f.memory.mu.Unlock()
+
+	f.memory.entries = make([]Entry, size)
+	f.memory.times = make([]time.Time, size)
+	f.memory.next = 0
+	f.memory.count = 0
+}
+
+// SetMemoryBufferMaxAge sets the age of its Time after which a kept entry is no longer returned by Last,
+// default is 0 which returns entries whatever their age
+func (f *FileLogger) SetMemoryBufferMaxAge(d time.Duration) {
+	f.memory.mu.Lock()
+	defer f.memory.mu.Unlock()
+
+	f.memory.maxAge = d
+}
+
+// Last returns up to the last n entries written, oldest first, see SetMemoryBuffer. Returns nil for n <= 0
+func (f *FileLogger) Last(n int) []Entry {
+	if n <= 0 {
+		return nil
+	}
+
+	r := &f.memory
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if n > r.count {
+		n = r.count
+	}
+
+	oldest := time.Time{}
+	if r.maxAge > 0 {
+		oldest = time.Now().Add(-r.maxAge)
+	}
+
+	last := make([]Entry, 0, n)
+	for i := r.next - n; i < r.next; i++ {
+		j := (i + len(r.entries)) % len(r.entries)
+		if r.times[j].Before(oldest) {
+			continue
+		}
+		last = append(last, *r.entries[j].clone())
+	}
+
+	return last
+}
+
+// keep a copy of e when the memory buffer is on
+func (f *FileLogger) remember(e *Entry) {
+	r := &f.memory
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) == 0 {
+		return
+	}
+
+	r.entries[r.next] = *e.clone()
+	r.times[r.next] = e.Time
+	r.next = (r.next + 1) % len(r.entries)
+	if r.count < len(r.entries) {
+		r.count++
+	}
+}
@@ -0,0 +1,88 @@
+package fileLogger
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLast(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+	l.SetMemoryBuffer(3)
+
+	for n := 0; n < 5; n++ {
+		l.I("entry %d", n)
+	}
+	l.Flush()
+
+	last := l.Last(10)
+	if len(last) != 3 {
+		t.Fatalf("Last(10) returned %v entries, want the 3 buffered", len(last))
+	}
+	for i, e := range last {
+		if want := fmt.Sprintf("entry %d", i+2); e.Msg != want {
+			t.Errorf("Last(10)[%v].Msg = %q, want %q", i, e.Msg, want)
+		}
+	}
+
+	for _, n := range []int{0, -1} {
+		if last := l.Last(n); last != nil {
+			t.Errorf("Last(%v) = %v, want nil", n, last)
+		}
+	}
+}
+
+// entries older than the max age are not returned, whatever their count
+func TestLastMaxAge(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+	l.SetMemoryBuffer(100)
+	l.SetMemoryBufferMaxAge(time.Minute)
+
+	// entries kept with the times of an hour ago and of now
+	now := time.Now()
+	for n := 0; n < 10; n++ {
+		l.remember(&Entry{Level: INFO, Msg: fmt.Sprintf("old %d", n), Time: now.Add(-time.Hour)})
+	}
+	for n := 0; n < 5; n++ {
+		l.remember(&Entry{Level: INFO, Msg: fmt.Sprintf("recent %d", n), Time: now})
+	}
+
+	last := l.Last(100)
+	if len(last) != 5 {
+		t.Fatalf("Last(100) returned %v entries, want the 5 recent ones", len(last))
+	}
+	for i, e := range last {
+		if want := fmt.Sprintf("recent %d", i); e.Msg != want {
+			t.Errorf("Last(100)[%v].Msg = %q, want %q", i, e.Msg, want)
+		}
+	}
+
+	l.SetMemoryBufferMaxAge(0)
+	if last := l.Last(100); len(last) != 15 {
+		t.Errorf("Last(100) without max age returned %v entries, want 15", len(last))
+	}
+}
+
+// as written by logWriter: 10 entries, a pause longer than the max age, then 5 more
+func TestLastMaxAgeWritten(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+	l.SetMemoryBuffer(100)
+	l.SetMemoryBufferMaxAge(200 * time.Millisecond)
+
+	for n := 0; n < 10; n++ {
+		l.I("old %d", n)
+	}
+	l.Flush()
+	time.Sleep(300 * time.Millisecond)
+	for n := 0; n < 5; n++ {
+		l.I("recent %d", n)
+	}
+	l.Flush()
+
+	if last := l.Last(100); len(last) != 5 || last[0].Msg != "recent 0" {
+		t.Errorf("Last(100) = %v, want the 5 recent entries", last)
+	}
+}
@@ -0,0 +1,77 @@
+// Package: fileLogger
+// File: metric.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: log metrics in the statsd format, to be forwarded from the log files later
+// DATE: 26-10-14 12:10
+package fileLogger
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type MetricType byte
+
+const (
+	MetricCounter MetricType = iota
+	MetricGauge
+	MetricTimer
+)
+
+// statsd type of each metric type
+var metricTypes = [...]string{
+	MetricCounter: "c",
+	MetricGauge:   "g",
+	MetricTimer:   "ms",
+}
+
+// Metric is a statsd counter, gauge or timer, the Value of a timer is in milliseconds
+type Metric struct {
+	Name  string
+	Value float64
+	Type  MetricType
+	Tags  map[string]string
+}
+
+// WriteMetric writes m at level with the statsd line name:value|type as message, type being c, g or ms,
+// and the tags in the DogStatsD manner: name:value|type|#tag1:val1,tag2:val2. Tags are sorted by name.
+// A metric without name or of an unknown type returns ErrInvalidMetric
+func (f *FileLogger) WriteMetric(level LEVEL, m Metric) error {
+	_, file, line, _ := runtime.Caller(1) //calldepth=2
+	if m.Name == "" || int(m.Type) >= len(metricTypes) {
+		return fmt.Errorf("%w: %+v", ErrInvalidMetric, m)
+	}
+	if !f.enabled(level) {
+		return nil
+	}
+
+	return f.send(f.entry(level, file, line, m.String(), nil))
+}
+
+// String returns the statsd line of m
+func (m Metric) String() string {
+	str := m.Name + ":" + strconv.FormatFloat(m.Value, 'f', -1, 64) + "|" + m.Type.String()
+	if len(m.Tags) == 0 {
+		return str
+	}
+
+	tags := make([]string, 0, len(m.Tags))
+	for k, v := range m.Tags {
+		tags = append(tags, k+":"+v)
+	}
+	sort.Strings(tags)
+
+	return str + "|#" + strings.Join(tags, ",")
+}
+
+// String returns the statsd type of t, such as "c"
+func (t MetricType) String() string {
+	if int(t) < len(metricTypes) {
+		return metricTypes[t]
+	}
+
+	return "MetricType(" + strconv.Itoa(int(t)) + ")"
+}
@@ -0,0 +1,73 @@
+// Package: fileLogger
+// File: override.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: log levels by calling package
+// DATE: 26-10-14 13:40
+package fileLogger
+
+import (
+	"strings"
+)
+
+// SetPackageLevelOverride sets the log level of the entries whose pkg field is packagePath or one of its
+// subpackages, in place of the level of SetLogLevel, e.g. to keep only the errors of a noisy library.
+// It takes effect with SetPackageAnnotation only. Of several overrides matching a package the longest path wins.
+// A level of OFF drops every leveled entry of the package
+func (f *FileLogger) SetPackageLevelOverride(packagePath string, level LEVEL) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.packageLevels == nil {
+		f.packageLevels = make(map[string]LEVEL)
+	}
+	f.packageLevels[strings.TrimSuffix(packagePath, "/")] = level
+	f.updatePackageLevelMin()
+}
+
+// store the lowest level of the overrides, OFF when they are not in effect. f.mu is held
+func (f *FileLogger) updatePackageLevelMin() {
+	min := OFF
+	if f.packageAnnotation.Load() {
+		for _, level := range f.packageLevels {
+			if level < min {
+				min = level
+			}
+		}
+	}
+
+	f.packageLevelMin.Store(int32(min))
+}
+
+// report whether an entry at level may be written, by the log level or, as it is known only
+// once the entry is built, by the level of a package override. levelAllowed decides for the entry
+func (f *FileLogger) enabled(level LEVEL) bool {
+	return LEVEL(f.logLevelValue.Load()) <= level || LEVEL(f.packageLevelMin.Load()) <= level
+}
+
+// report whether e is at or above the level of its package's override, or the log level by default.
+// Entries of Print() are always allowed, an entry is dropped when the overrides can not be read
+// within the lock timeout
+func (f *FileLogger) levelAllowed(e *Entry) bool {
+	if e.Level >= OFF {
+		return true
+	}
+
+	threshold, match := LEVEL(f.logLevelValue.Load()), ""
+	if !f.packageAnnotation.Load() {
+		return e.Level >= threshold
+	}
+
+	if !f.rlock() {
+		return false
+	}
+	defer f.mu.RUnlock()
+
+	pkg, _ := e.Fields["pkg"].(string)
+	for path, level := range f.packageLevels {
+		if len(path) > len(match) && (pkg == path || strings.HasPrefix(pkg, path+"/")) {
+			threshold, match = level, path
+		}
+	}
+
+	return e.Level >= threshold
+}
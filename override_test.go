@@ -0,0 +1,89 @@
+package fileLogger
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// the tests are in the package itself, the pkg of their entries is testing
+func TestPackageLevelOverride(t *testing.T) {
+	tests := []struct {
+		name      string
+		logLevel  LEVEL
+		overrides map[string]LEVEL
+		want      []string
+		dropped   []string
+	}{
+		{"raise", TRACE, map[string]LEVEL{"testing": ERROR}, []string{"error"}, []string{"trace", "info"}},
+		{"lower", ERROR, map[string]LEVEL{"testing": TRACE}, []string{"trace", "info", "error"}, nil},
+		{"longest wins", TRACE, map[string]LEVEL{"testing": INFO, "test": ERROR}, []string{"info", "error"}, []string{"trace"}},
+		{"path boundary", ERROR, map[string]LEVEL{"test": TRACE}, []string{"error"}, []string{"trace", "info"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			l := NewPlainLogger(dir, "a.log", "")
+			defer l.Close()
+			l.SetLogLevel(tt.logLevel)
+			l.SetPackageAnnotation(true)
+			for path, level := range tt.overrides {
+				l.SetPackageLevelOverride(path, level)
+			}
+
+			l.T("trace")
+			l.I("info")
+			l.E("error")
+			l.Flush()
+
+			content := readLog(t, filepath.Join(dir, "a.log"))
+			for _, msg := range tt.want {
+				if !strings.Contains(content, "] "+msg) {
+					t.Errorf("a.log = %q, want %q", content, msg)
+				}
+			}
+			for _, msg := range tt.dropped {
+				if strings.Contains(content, "] "+msg) {
+					t.Errorf("a.log = %q, want %q dropped", content, msg)
+				}
+			}
+		})
+	}
+}
+
+func TestPackageLevelOverrideWithoutAnnotation(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.SetLogLevel(ERROR)
+	l.SetPackageLevelOverride("testing", TRACE)
+
+	l.T("trace")
+	l.Flush()
+
+	if content := readLog(t, filepath.Join(dir, "a.log")); strings.Contains(content, "trace") {
+		t.Errorf("a.log = %q, want the override ignored without SetPackageAnnotation", content)
+	}
+}
+
+func TestPackageLevelOverrideWhileLogging(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+	l.SetPackageAnnotation(true)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 100; n++ {
+			l.T("entry")
+		}
+	}()
+	for n := 0; n < 100; n++ {
+		l.SetPackageLevelOverride("testing", LEVEL(n%4))
+		l.SetLogLevel(LEVEL(n % 4))
+	}
+	wg.Wait()
+}
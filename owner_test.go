@@ -0,0 +1,78 @@
+//go:build !windows
+
+package fileLogger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// uid and gid of path
+func owner(t *testing.T, path string) (int, int) {
+	t.Helper()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stat := info.Sys().(*syscall.Stat_t)
+
+	return int(stat.Uid), int(stat.Gid)
+}
+
+func TestSetCurrentUser(t *testing.T) {
+	dir := t.TempDir()
+	l := NewSizeLogger(dir, "a.log", "", 1, 1, MB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+	defer l.Close()
+
+	if err := l.SetCurrentUser(); err != nil {
+		t.Fatalf("chown to the current user: %v", err)
+	}
+
+	l.I("entry")
+	l.Flush()
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.log", "a.log.1"} {
+		if uid, gid := owner(t, filepath.Join(dir, name)); uid != os.Getuid() || gid != os.Getgid() {
+			t.Errorf("%v is owned by %d:%d, want %d:%d", name, uid, gid, os.Getuid(), os.Getgid())
+		}
+	}
+}
+
+func TestSetOwnerInvalid(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+
+	for _, ids := range [][2]int{{-5, os.Getgid()}, {os.Getuid(), -5}} {
+		if err := l.SetOwner(ids[0], ids[1]); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("SetOwner(%d, %d) = %v, want ErrInvalidConfig", ids[0], ids[1], err)
+		}
+	}
+
+	l.mu.RLock()
+	owned := l.owned
+	l.mu.RUnlock()
+	if owned {
+		t.Error("invalid owner kept")
+	}
+}
+
+// only root can give a file away
+func TestSetOwnerNotPermitted(t *testing.T) {
+	if os.Getuid() == 0 {
+		t.Skip("root can chown to any uid")
+	}
+
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	if err := l.SetOwner(0, 0); !errors.Is(err, ErrInvalidConfig) || !errors.Is(err, os.ErrPermission) {
+		t.Errorf("SetOwner(0, 0) = %v, want ErrInvalidConfig wrapping os.ErrPermission", err)
+	}
+}
@@ -0,0 +1,42 @@
+// Package: fileLogger
+// File: panic.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: log the panics of goroutines before the process exits
+// DATE: 26-10-14 13:00
+package fileLogger
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+)
+
+const (
+	PANIC_EXIT_CODE = 2
+)
+
+// SafeGo runs fn in a new goroutine. A panic of fn is recovered and written to fl at ERROR
+// with the panic value and the goroutine's stack as the fields panic and stack,
+// then fl is flushed and the process exits with PANIC_EXIT_CODE, like an unrecovered panic would.
+// The entry carries the file and line SafeGo was called from
+func SafeGo(fl *FileLogger, fn func()) {
+	_, file, line, _ := runtime.Caller(1) //calldepth=2
+
+	go func() {
+		defer func() {
+			if err := recover(); err != nil {
+				e := fl.entry(ERROR, file, line, fmt.Sprintf("panic: %v", err), nil)
+				e.typed = append(e.typed,
+					StringField{Key: "panic", Value: fmt.Sprint(err)},
+					StringField{Key: "stack", Value: string(debug.Stack())},
+				)
+				fl.send(e)
+				fl.Flush()
+				os.Exit(PANIC_EXIT_CODE)
+			}
+		}()
+
+		fn()
+	}()
+}
@@ -0,0 +1,95 @@
+package fileLogger
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// SafeGo exits the process, the panicking goroutine runs in a child test process
+func TestSafeGo(t *testing.T) {
+	if dir := os.Getenv("FILELOGGER_SAFEGO_DIR"); dir != "" {
+		l := NewPlainLogger(dir, "a.log", "")
+		SafeGo(l, func() { panic("boom") })
+		time.Sleep(10 * time.Second)
+		os.Exit(0)
+	}
+
+	dir := t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestSafeGo$")
+	cmd.Env = append(os.Environ(), "FILELOGGER_SAFEGO_DIR="+dir)
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != PANIC_EXIT_CODE {
+		t.Fatalf("child exited with %v, want exit status %v", err, PANIC_EXIT_CODE)
+	}
+
+	content := readLog(t, filepath.Join(dir, "a.log"))
+	for _, want := range []string{"[ERROR] panic: boom", "panic=boom", "stack=", "TestSafeGo"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("a.log = %q, want it to hold %q", content, want)
+		}
+	}
+}
+
+func TestFlush(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+
+	for n := 0; n < 100; n++ {
+		l.I("entry %d", n)
+	}
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if content := readLog(t, filepath.Join(dir, "a.log")); !strings.Contains(content, "entry 99") {
+		t.Errorf("a.log = %q, want every entry queued before Flush", content)
+	}
+}
+
+func TestFlushWhileClosing(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		l := NewPlainLogger(t.TempDir(), "a.log", "")
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				l.I("entry")
+				if err := l.Flush(); err != nil && !errors.Is(err, ErrClosed) {
+					t.Errorf("Flush() = %v, want nil or ErrClosed", err)
+					return
+				}
+			}
+		}()
+		l.Close()
+		wg.Wait()
+	}
+}
+
+// a Flush waiting on a logWriter that has stopped returns instead of blocking forever
+func TestFlushWriterStopped(t *testing.T) {
+	f := &FileLogger{
+		mu:      new(sync.RWMutex),
+		logChan: make(chan *Entry),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	time.AfterFunc(10*time.Millisecond, func() { close(f.stopped) })
+
+	if err := f.Flush(); !errors.Is(err, ErrWriterStopped) {
+		t.Errorf("Flush() = %v, want ErrWriterStopped", err)
+	}
+	if err := f.WriteString(INFO, "entry"); !errors.Is(err, ErrWriterStopped) {
+		t.Errorf("WriteString() = %v, want ErrWriterStopped", err)
+	}
+}
@@ -0,0 +1,143 @@
+// Package: fileLogger
+// File: quota.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: limit the bytes written per period, e.g. for log ingestion billed by volume
+// DATE: 26-10-14 10:20
+package fileLogger
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// bytes allowed per period, used is only added to by logWriter and reset by resetQuota
+type quota struct {
+	name         string
+	period       time.Duration
+	maxBytes     int64
+	used         atomic.Int64
+	droppedBytes atomic.Int64
+	stop         chan struct{}
+}
+
+// SetQuota limits the bytes written to the log file per period, lines included, whatever their level.
+// Once the quota is used up, entries are dropped until the period ends: the methods returning an error
+// return ErrQuotaExceeded, the others drop silently. Dropped entries are counted in Stats().QuotaDroppedCount,
+// and the bytes dropped over a period are logged when the next one starts.
+// A period or maxBytes <= 0 removes the quota
+func (f *FileLogger) SetQuota(period time.Duration, maxBytes int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.quota.close()
+	f.quota = f.newQuota("", period, maxBytes)
+}
+
+// SetLevelQuota limits the bytes the entries of level write per period, see SetQuota.
+// Entries are charged to both their level's quota and the quota of SetQuota
+func (f *FileLogger) SetLevelQuota(level LEVEL, period time.Duration, maxBytes int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.levelQuotas == nil {
+		f.levelQuotas = make(map[LEVEL]*quota)
+	}
+	f.levelQuotas[level].close()
+	if q := f.newQuota(level.String(), period, maxBytes); q != nil {
+		f.levelQuotas[level] = q
+	} else {
+		delete(f.levelQuotas, level)
+	}
+}
+
+// return a quota resetting every period, nil when there is none
+func (f *FileLogger) newQuota(name string, period time.Duration, maxBytes int64) *quota {
+	if period <= 0 || maxBytes <= 0 {
+		return nil
+	}
+
+	q := &quota{name: name, period: period, maxBytes: maxBytes, stop: make(chan struct{})}
+	go f.resetQuota(q)
+
+	return q
+}
+
+// reset the bytes used by q each period and log what was dropped, until q is replaced or f is closed
+func (f *FileLogger) resetQuota(q *quota) {
+	ticker := time.NewTicker(q.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.stop:
+			return
+		case <-ticker.C:
+			q.used.Store(0)
+			dropped := q.droppedBytes.Swap(0)
+
+			f.mu.RLock()
+			closed := f.closed
+			if dropped > 0 && !closed {
+				if q.name == "" {
+					f.printf("[INFO] FileLogger quota dropped %v bytes in the last %v", dropped, q.period)
+				} else {
+					f.printf("[INFO] FileLogger %v quota dropped %v bytes in the last %v", q.name, dropped, q.period)
+				}
+			}
+			f.mu.RUnlock()
+
+			if closed {
+				return
+			}
+		}
+	}
+}
+
+// charge a line of n bytes of level to its quotas, false when it does not fit and is dropped.
+// A quota a line does not fit in is used up for the rest of the period. Called by p with f.mu held
+func (f *FileLogger) chargeQuota(level LEVEL, n int64) bool {
+	all, lvl := f.quota, f.levelQuotas[level]
+	if all.exceeds(n) || lvl.exceeds(n) {
+		for _, q := range []*quota{all, lvl} {
+			if q.exceeds(n) {
+				q.used.Store(q.maxBytes)
+			}
+		}
+		all.drop(n)
+		lvl.drop(n)
+		f.quotaDroppedCount.Add(1)
+		return false
+	}
+
+	all.add(n)
+	lvl.add(n)
+	return true
+}
+
+// the methods below accept a nil quota, which never runs out
+
+func (q *quota) exceeds(n int64) bool {
+	return q != nil && q.used.Load()+n > q.maxBytes
+}
+
+func (q *quota) full() bool {
+	return q != nil && q.used.Load() >= q.maxBytes
+}
+
+func (q *quota) add(n int64) {
+	if q != nil {
+		q.used.Add(n)
+	}
+}
+
+func (q *quota) drop(n int64) {
+	if q != nil {
+		q.droppedBytes.Add(n)
+	}
+}
+
+func (q *quota) close() {
+	if q != nil {
+		close(q.stop)
+	}
+}
@@ -0,0 +1,91 @@
+package fileLogger
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const QUOTA_PERIOD = 500 * time.Millisecond
+
+// write an entry of level from the same call site, so every line has the same length
+func writeQuota(l *FileLogger, level LEVEL) error {
+	err := l.WriteString(level, "entry")
+	l.Flush()
+	return err
+}
+
+// bytes of a line written by writeQuota
+func quotaLineSize(t *testing.T, l *FileLogger) int64 {
+	if err := writeQuota(l, INFO); err != nil {
+		t.Fatal(err)
+	}
+	return fileSize(filepath.Join(l.fileDir, l.fileName))
+}
+
+func TestQuota(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	n := quotaLineSize(t, l)
+	l.SetQuota(QUOTA_PERIOD, 2*n)
+
+	for i := 0; i < 2; i++ {
+		if err := writeQuota(l, INFO); err != nil {
+			t.Fatalf("entry %d within the quota: %v", i, err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if err := writeQuota(l, ERROR); !errors.Is(err, ErrQuotaExceeded) {
+			t.Fatalf("entry %d past the quota: %v, want ErrQuotaExceeded", i, err)
+		}
+	}
+	if dropped := l.Stats().QuotaDroppedCount; dropped != 2 {
+		t.Errorf("QuotaDroppedCount is %d, want 2", dropped)
+	}
+	if size := fileSize(filepath.Join(l.fileDir, l.fileName)); size != 3*n {
+		t.Errorf("log file holds %d bytes, want %d", size, 3*n)
+	}
+
+	time.Sleep(QUOTA_PERIOD + QUOTA_PERIOD/5)
+	if err := writeQuota(l, INFO); err != nil {
+		t.Errorf("entry in the next period: %v", err)
+	}
+	if dropped := l.Stats().QuotaDroppedCount; dropped != 2 {
+		t.Errorf("QuotaDroppedCount is %d in the next period, want still 2", dropped)
+	}
+}
+
+func TestLevelQuota(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	n := quotaLineSize(t, l)
+	l.SetLevelQuota(WARN, QUOTA_PERIOD, n)
+
+	if err := writeQuota(l, WARN); err != nil {
+		t.Fatalf("entry within the quota: %v", err)
+	}
+	if err := writeQuota(l, WARN); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("entry past the quota: %v, want ErrQuotaExceeded", err)
+	}
+	if err := writeQuota(l, INFO); err != nil {
+		t.Errorf("entry of another level: %v", err)
+	}
+	if dropped := l.Stats().QuotaDroppedCount; dropped != 1 {
+		t.Errorf("QuotaDroppedCount is %d, want 1", dropped)
+	}
+
+	time.Sleep(QUOTA_PERIOD + QUOTA_PERIOD/5)
+	if err := writeQuota(l, WARN); err != nil {
+		t.Errorf("entry in the next period: %v", err)
+	}
+
+	l.SetLevelQuota(WARN, 0, 0)
+	for i := 0; i < 3; i++ {
+		if err := writeQuota(l, WARN); err != nil {
+			t.Errorf("entry %d once the quota is removed: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package fileLogger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func readLog(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func TestRotateSize(t *testing.T) {
+	dir := t.TempDir()
+	l := NewSizeLogger(dir, "a.log", "", 3, 1, MB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+	defer l.Close()
+
+	l.I("before rotate")
+	l.Flush()
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	l.I("after rotate")
+	l.Flush()
+
+	if bak := readLog(t, filepath.Join(dir, "a.log.1")); !strings.Contains(bak, "before rotate") {
+		t.Errorf("a.log.1 = %q, want the entry written before Rotate", bak)
+	}
+	if cur := readLog(t, filepath.Join(dir, "a.log")); strings.Contains(cur, "before rotate") || !strings.Contains(cur, "after rotate") {
+		t.Errorf("a.log = %q, want only the entry written after Rotate", cur)
+	}
+}
+
+func TestRotateWithoutFileCount(t *testing.T) {
+	dir := t.TempDir()
+	l := NewSizeLogger(dir, "a.log", "", 0, 1, KB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+	defer l.Close()
+
+	if err := l.Rotate(); !errors.Is(err, ErrRotationFailed) {
+		t.Errorf("Rotate() = %v, want ErrRotationFailed", err)
+	}
+}
+
+func TestSplitOnErrorWithoutFileCount(t *testing.T) {
+	dir := t.TempDir()
+	l := NewSizeLogger(dir, "a.log", "", 0, 1, KB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+	defer l.Close()
+	l.SetSplitOnError(true)
+
+	l.E("boom")
+	l.I("still logging")
+	l.Flush()
+
+	if cur := readLog(t, filepath.Join(dir, "a.log")); !strings.Contains(cur, "still logging") {
+		t.Errorf("a.log = %q, want the entry written after the ERROR", cur)
+	}
+}
+
+func TestRotateDaily(t *testing.T) {
+	dir := t.TempDir()
+	l := NewDailyLogger(dir, "a.log", "", DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+	defer l.Close()
+
+	l.I("first")
+	l.Flush()
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	baks, _ := filepath.Glob(filepath.Join(dir, "a.log.*.1"))
+	if len(baks) != 1 || !strings.Contains(readLog(t, baks[0]), "first") {
+		t.Errorf("bak files %v, want one <date>.1 holding the first entry", baks)
+	}
+}
+
+func TestRotatePlain(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	if err := l.Rotate(); !errors.Is(err, ErrRotationFailed) {
+		t.Errorf("Rotate() = %v, want ErrRotationFailed", err)
+	}
+}
+
+// 20 writers log an entry every millisecond for 10s to a size logger split every second, while 2 verifiers
+// check that every bak file holds whole lines of UTF-8. Every entry written is then found exactly once
+func TestRotationStress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("rotation stress test runs for 10s")
+	}
+
+	dir := t.TempDir()
+	l := NewSizeLogger(dir, "a.log", "", 50, 10, KB, 1, DEFAULT_LOG_SEQ)
+	defer l.Close()
+
+	stop := make(chan struct{})
+	var writers, verifiers sync.WaitGroup
+	written := make([][]string, 20)
+	for g := range written {
+		writers.Add(1)
+		go func(g int) {
+			defer writers.Done()
+			ticker := time.NewTicker(time.Millisecond)
+			defer ticker.Stop()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+				}
+				msg := fmt.Sprintf("stress g=%02d i=%06d", g, i)
+				if err := l.WriteString(INFO, msg); err != nil {
+					t.Errorf("WriteString() = %v", err)
+					return
+				}
+				written[g] = append(written[g], msg)
+			}
+		}(g)
+	}
+
+	for v := 0; v < 2; v++ {
+		verifiers.Add(1)
+		go func() {
+			defer verifiers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-time.After(100 * time.Millisecond):
+				}
+				baks, _ := filepath.Glob(filepath.Join(dir, "a.log.*"))
+				for _, bak := range baks {
+					content, err := os.ReadFile(bak)
+					if os.IsNotExist(err) {
+						// removed by a split since the Glob
+						continue
+					}
+					if err != nil {
+						t.Errorf("read %v: %v", bak, err)
+						return
+					}
+					if !utf8.Valid(content) || len(content) > 0 && content[len(content)-1] != '\n' {
+						t.Errorf("%v is not whole lines of UTF-8", bak)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	time.Sleep(10 * time.Second)
+	close(stop)
+	writers.Wait()
+	verifiers.Wait()
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	baks, _ := filepath.Glob(filepath.Join(dir, "a.log.*"))
+	if len(baks) == 0 {
+		t.Fatal("no bak file, the log file was never split")
+	}
+	found := make(map[string]int)
+	for _, path := range append(baks, filepath.Join(dir, "a.log")) {
+		for _, line := range strings.Split(readLog(t, path), "\n") {
+			// the message is followed by the color codes of the level
+			if i := strings.Index(line, "stress "); i >= 0 {
+				found[line[i:i+len("stress g=00 i=000000")]]++
+			}
+		}
+	}
+
+	total := 0
+	for _, msgs := range written {
+		total += len(msgs)
+		for _, msg := range msgs {
+			if found[msg] != 1 {
+				t.Fatalf("%q found %d times, want once", msg, found[msg])
+			}
+		}
+	}
+	if len(found) != total {
+		t.Errorf("found %d entries, want the %d written", len(found), total)
+	}
+}
@@ -0,0 +1,73 @@
+// Package: fileLogger
+// File: rotationlock.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: keep several fileLoggers of the same log file from splitting it twice
+// DATE: 26-10-14 11:50
+package fileLogger
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	ROTATION_LOCK_SUFFIX = ".rotating"
+	// a lock file older than this was left by a process dying while splitting
+	ROTATION_LOCK_STALE = time.Minute
+)
+
+// create the lock file of logFile, false when another fileLogger holds it
+func lockRotation(logFile string) bool {
+	lockFile := logFile + ROTATION_LOCK_SUFFIX
+	for retry := 0; retry < 2; retry++ {
+		lock, err := os.OpenFile(lockFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+		if err == nil {
+			lock.Close()
+			return true
+		}
+
+		info, statErr := os.Stat(lockFile)
+		if !os.IsExist(err) || statErr != nil || time.Since(info.ModTime()) < ROTATION_LOCK_STALE {
+			return false
+		}
+		os.Remove(lockFile)
+	}
+
+	return false
+}
+
+// follow the split another fileLogger is doing or has done: the log file is reopened, by then it either is
+// the new log file or still the old one which will be split at next check. f.mu is held
+func (f *FileLogger) skipSplit(logFile string) {
+	if f.logFile != nil {
+		f.logFile.Close()
+	}
+	f.logFile, _ = os.OpenFile(logFile, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0666)
+	f.chown(logFile)
+
+	if f.splitType == SplitType_Daily && isExist(logFile+"."+f.date.Format(DATEFORMAT)) {
+		t, _ := time.Parse(DATEFORMAT, f.now().Format(DATEFORMAT))
+		f.date = &t
+	}
+	if f.offsetIndex {
+		f.resetIndex()
+	}
+}
+
+// isSplitAway reports whether the log file f writes has been renamed by the split of another fileLogger
+func (f *FileLogger) isSplitAway() bool {
+	if f.logFile == nil {
+		return false
+	}
+
+	current, err := os.Stat(joinFilePath(f.fileDir, f.fileName))
+	if err != nil {
+		return false
+	}
+	opened, err := f.logFile.Stat()
+	if err != nil {
+		return false
+	}
+
+	return !os.SameFile(current, opened)
+}
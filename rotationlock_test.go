@@ -0,0 +1,70 @@
+package fileLogger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// a and b both found the file over its size, a splits first: b must follow a's split rather than split again
+func TestRotationLockSplitsOnce(t *testing.T) {
+	dir := t.TempDir()
+	a := NewSizeLogger(dir, "a.log", "", 10, 1, KB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+	b := NewSizeLogger(dir, "a.log", "", 10, 1, KB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+	defer a.Close()
+	defer b.Close()
+	a.SetRotationLockFile(true)
+	b.SetRotationLockFile(true)
+
+	a.I("%v", strings.Repeat("a", 2048))
+	a.Flush()
+	if !a.isMustSplit() || !b.isMustSplit() {
+		t.Fatal("a.log is not over its size")
+	}
+
+	a.fileCheck()
+	b.mu.Lock()
+	err := b.split()
+	b.mu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b.I("after split")
+	b.Flush()
+
+	baks, _ := filepath.Glob(filepath.Join(dir, "a.log.[0-9]*"))
+	if len(baks) != 1 {
+		t.Fatalf("bak files %v, want only a.log.1", baks)
+	}
+	if bak := readLog(t, baks[0]); !strings.Contains(bak, strings.Repeat("a", 2048)) {
+		t.Errorf("%v does not hold the entry written before the split", baks[0])
+	}
+	if cur := readLog(t, filepath.Join(dir, "a.log")); !strings.Contains(cur, "after split") {
+		t.Errorf("a.log = %q, want the entry b wrote after following the split", cur)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a.log"+ROTATION_LOCK_SUFFIX)); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind: %v", err)
+	}
+}
+
+func TestRotationLockHeld(t *testing.T) {
+	dir := t.TempDir()
+	l := NewSizeLogger(dir, "a.log", "", 10, 1, KB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+	defer l.Close()
+	l.SetRotationLockFile(true)
+
+	lockFile := filepath.Join(dir, "a.log"+ROTATION_LOCK_SUFFIX)
+	if !lockRotation(filepath.Join(dir, "a.log")) {
+		t.Fatal("lockRotation() = false on a free lock")
+	}
+	defer os.Remove(lockFile)
+
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	if baks, _ := filepath.Glob(filepath.Join(dir, "a.log.[0-9]*")); len(baks) != 0 {
+		t.Errorf("bak files %v while another fileLogger holds the lock", baks)
+	}
+}
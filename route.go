@@ -0,0 +1,72 @@
+// Package: fileLogger
+// File: route.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: route entries to per-component loggers by the prefix of their message
+// DATE: 26-10-14 11:00
+package fileLogger
+
+import (
+	"strings"
+)
+
+type PrefixRouteMode byte
+
+const (
+	RouteCopy      PrefixRouteMode = iota // write routed entries to both loggers
+	RouteExclusive                        // write routed entries to the matched logger only
+)
+
+// SetPrefixRouter sets the loggers entries are routed to by the prefix of their message,
+// e.g. {"[HTTP]": httpLogger, "[DB]": dbLogger}. The longest matching prefix wins.
+// Routed entries have gone through f's transform hooks and go through the matched logger's as well,
+// they are not routed again by the matched logger's router. A nil or empty router routes nothing
+func (f *FileLogger) SetPrefixRouter(router map[string]*FileLogger) {
+	routes := make(map[string]*FileLogger, len(router))
+	for prefix, logger := range router {
+		if logger != nil && logger != f {
+			routes[prefix] = logger
+		}
+	}
+
+	f.mu.Lock()
+	f.router = routes
+	f.mu.Unlock()
+}
+
+// SetPrefixRouteMode sets whether routed entries are also written by f, default is RouteCopy
+func (f *FileLogger) SetPrefixRouteMode(mode PrefixRouteMode) {
+	f.mu.Lock()
+	f.routeMode = mode
+	f.mu.Unlock()
+}
+
+// send a copy of e to the logger its message prefix routes to, reporting whether f still writes e
+func (f *FileLogger) route(e *Entry) bool {
+	// routers routing to each other would send it back and forth
+	if e.routed {
+		return true
+	}
+
+	f.mu.RLock()
+	router, mode := f.router, f.routeMode
+	f.mu.RUnlock()
+
+	var target *FileLogger
+	matched := ""
+	for prefix, logger := range router {
+		if strings.HasPrefix(e.Msg, prefix) && len(prefix) >= len(matched) {
+			target, matched = logger, prefix
+		}
+	}
+	if target == nil {
+		return true
+	}
+
+	routed := e.clone()
+	routed.routed = true
+	if err := target.send(routed); err != nil {
+		return true
+	}
+
+	return mode == RouteCopy
+}
@@ -0,0 +1,64 @@
+package fileLogger
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPrefixRouter(t *testing.T) {
+	for _, mode := range []PrefixRouteMode{RouteCopy, RouteExclusive} {
+		dir := t.TempDir()
+		l := NewPlainLogger(dir, "app.log", "")
+		httpLogger := NewPlainLogger(dir, "http.log", "")
+		dbLogger := NewPlainLogger(dir, "db.log", "")
+		l.SetPrefixRouter(map[string]*FileLogger{"[HTTP]": httpLogger, "[DB]": dbLogger, "[DB] slow": httpLogger})
+		l.SetPrefixRouteMode(mode)
+
+		l.I("[HTTP] GET /")
+		l.I("[DB] query")
+		l.I("[DB] slow query")
+		l.I("started")
+		l.Close()
+		httpLogger.Close()
+		dbLogger.Close()
+
+		app := readLog(t, filepath.Join(dir, "app.log"))
+		http := readLog(t, filepath.Join(dir, "http.log"))
+		db := readLog(t, filepath.Join(dir, "db.log"))
+		if !strings.Contains(http, "[HTTP] GET /") || !strings.Contains(http, "[DB] slow query") || strings.Contains(http, "[DB] query") {
+			t.Errorf("mode %v: http.log = %q, want the [HTTP] entry and the longest match [DB] slow", mode, http)
+		}
+		if !strings.Contains(db, "[DB] query") || strings.Contains(db, "slow") || strings.Contains(db, "HTTP") {
+			t.Errorf("mode %v: db.log = %q, want only the [DB] entry", mode, db)
+		}
+		if !strings.Contains(app, "started") {
+			t.Errorf("mode %v: app.log = %q, want the entry matching no prefix", mode, app)
+		}
+		if copied := strings.Contains(app, "[HTTP] GET /") && strings.Contains(app, "[DB] query"); copied != (mode == RouteCopy) {
+			t.Errorf("mode %v: app.log = %q, want the routed entries only with RouteCopy", mode, app)
+		}
+	}
+}
+
+// two loggers routing the same prefix to each other write the entry once each, the copy is not routed back
+func TestPrefixRouterLoop(t *testing.T) {
+	dir := t.TempDir()
+	a := NewPlainLogger(dir, "a.log", "")
+	b := NewPlainLogger(dir, "b.log", "")
+	a.SetPrefixRouter(map[string]*FileLogger{"[X]": b})
+	b.SetPrefixRouter(map[string]*FileLogger{"[X]": a})
+
+	a.I("[X] entry")
+	a.Flush()
+	b.Flush()
+	a.Flush()
+	a.Close()
+	b.Close()
+
+	for _, name := range []string{"a.log", "b.log"} {
+		if n := strings.Count(readLog(t, filepath.Join(dir, name)), "[X] entry"); n != 1 {
+			t.Errorf("%v holds the entry %d times, want once", name, n)
+		}
+	}
+}
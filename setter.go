@@ -5,6 +5,16 @@
 // DATE: 14-8-24 11:14
 package fileLogger
 
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
 // Change the sizeSplit fileLogger's bak file count
 func (f *FileLogger) SetMaxFileCount(count int) int {
 	f.fileCount = count
@@ -19,12 +29,28 @@ func (f *FileLogger) SetMaxFileSize(size int64, unit UNIT) int64 {
 
 // SetPrefix sets the output prefix for the logger.
 func (f *FileLogger) SetPrefix(prefix string) {
-	f.lg.SetPrefix(prefix)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.prefix = prefix
 }
 
 // SetFlags sets the output flags for the logger.
 func (f *FileLogger) SetFlags(flag int) {
-	f.lg.SetFlags(flag)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.flag = flag
+}
+
+// SetLineEnding sets the string ending each log line, default is "\n", e.g. "\r\n" for windows tools.
+// Beware that ForwardFile, the offset index and most line-parsing tools split lines on "\n",
+// an ending without one makes them see a whole file as a single line
+func (f *FileLogger) SetLineEnding(ending string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lineEnding = ending
 }
 
 // SetLogSeq sets the logChan's buffer size
@@ -39,7 +65,30 @@ func (f *FileLogger) SetLogScanInterval(interval int) {
 
 // SetLogLevel sets the output log's Level: TRACE<INFO<WARN<ERROR<OFF
 func (f *FileLogger) SetLogLevel(level LEVEL) {
+	f.levelMu.Lock()
+	event := LevelChangeEvent{OldLevel: f.logLevel, NewLevel: level, ChangedAt: f.now()}
 	f.logLevel = level
+	f.logLevelValue.Store(int32(level))
+	for ch := range f.levelChans {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	callback := f.levelCallback
+	f.levelMu.Unlock()
+
+	if callback != nil {
+		callback(event)
+	}
+}
+
+// SetLevelChangeCallback sets the func called after each SetLogLevel, nil removes it
+func (f *FileLogger) SetLevelChangeCallback(fn func(LevelChangeEvent)) {
+	f.levelMu.Lock()
+	defer f.levelMu.Unlock()
+
+	f.levelCallback = fn
 }
 
 // SetLogConsole sets whether the log string will print in console, default is false
@@ -47,6 +96,223 @@ func (f *FileLogger) SetLogConsole(console bool) {
 	f.logConsole = console
 }
 
+// SetErrorTransformer sets whether an error passed to the log methods is also written as
+// structured fields (error_chain, op, url, path...), default is false
+func (f *FileLogger) SetErrorTransformer(enabled bool) {
+	f.errorTransformer.Store(enabled)
+}
+
+// SetPackageAnnotation sets whether entries get a pkg field holding the import path of the calling package,
+// e.g. github.com/aiwuTech/fileLogger/example, default is false. The call stack is walked for each entry
+func (f *FileLogger) SetPackageAnnotation(enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.packageAnnotation.Store(enabled)
+	f.updatePackageLevelMin()
+}
+
+// SetSensitiveHeaders sets the http headers WriteHTTP and WriteResponse write as REDACTED, replacing the default
+// Authorization, Proxy-Authorization, Cookie and Set-Cookie. Names are case insensitive
+func (f *FileLogger) SetSensitiveHeaders(headers ...string) {
+	sensitive := make(map[string]bool, len(headers))
+	for _, h := range headers {
+		sensitive[http.CanonicalHeaderKey(h)] = true
+	}
+
+	f.mu.Lock()
+	f.sensitiveHeaders = sensitive
+	f.mu.Unlock()
+}
+
+// SetSlowWriteThreshold sets the latency above which a write to the log file is reported on stderr,
+// default is 0 which reports nothing
+func (f *FileLogger) SetSlowWriteThreshold(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.slowWriteThreshold = d
+}
+
+// SetFormatter sets the formatter of log entries, default is TextFormatter
+func (f *FileLogger) SetFormatter(formatter Formatter) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.formatter = formatter
+}
+
+// SetContextKey sets the context.Context key carrying the contextID made by NewContext,
+// set it before the first NewContext call. Default is a key private to fileLogger
+func (f *FileLogger) SetContextKey(key interface{}) {
+	f.ctxKey.Store(&key)
+}
+
+// SetChecksumOnRotate sets the algorithm (md5, sha1 or sha256) used to checksum each new bak file
+// into checksums.json of fileDir, see VerifyAll. Default is "" which records nothing
+func (f *FileLogger) SetChecksumOnRotate(algorithm string) error {
+	if algorithm != "" {
+		if _, err := newHash(algorithm); err != nil {
+			return err
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.checksumAlgorithm = algorithm
+	return nil
+}
+
+// AddTransformHook adds a hook run on each entry before it is formatted, hooks run in the order added
+func (f *FileLogger) AddTransformHook(hook TransformHook) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.transformHooks = append(f.transformHooks, hook)
+}
+
+// SetOffsetIndex sets whether the offset of each line of the current log file is kept, see SeekToEntry.
+// Enabling it loads the index from its .oidx sidecar file or rebuilds it from the log file,
+// disabling it saves the index to the sidecar file, so does Close. Default is false
+func (f *FileLogger) SetOffsetIndex(enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.offsetIndex == enabled {
+		return
+	}
+	f.offsetIndex = enabled
+
+	if enabled {
+		f.loadIndex()
+	} else if err := f.saveIndex(); err != nil {
+		f.printf("FileLogger offset index error: %v", err)
+	}
+}
+
+// SetTimezone sets the timezone used by log timestamps and daily bak file names, default is local
+func (f *FileLogger) SetTimezone(loc *time.Location) {
+	f.loc.Store(loc)
+}
+
+// SetUTC is short for SetTimezone(time.UTC)
+func (f *FileLogger) SetUTC() {
+	f.SetTimezone(time.UTC)
+}
+
+// SetHostnameSubdir sets whether the log files are written into a subdirectory named by the hostname,
+// so that nodes sharing one storage never write to the same file, default is false.
+// The log file opened until then is removed when it is still empty, Config.HostnameSubdir never creates it
+func (f *FileLogger) SetHostnameSubdir(enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.hostnameSubdir == enabled {
+		return
+	}
+	f.hostnameSubdir = enabled
+
+	prevDir := f.fileDir
+	if enabled {
+		f.fileDir = joinFilePath(f.fileDir, hostname())
+	} else {
+		f.fileDir = filepath.Dir(f.fileDir)
+	}
+
+	if f.logFile != nil {
+		f.logFile.Close()
+		f.logFile = nil
+	}
+	// e.g. the file the constructor created in the shared directory
+	if left := joinFilePath(prevDir, f.fileName); isExist(left) && fileSize(left) == 0 {
+		os.Remove(left)
+	}
+	if f.splitType == SplitType_Size || f.splitType == SplitType_HybridScheduled {
+		f.loadSuffix()
+	}
+	if err := f.openLogFile(); err != nil {
+		log.Printf("FileLogger reopen error: %v", err)
+	}
+}
+
+// SetStartupCheck sets whether fileDir and the log file are checked writable each time the log file
+// is opened, default is true. The check made by the constructors is reported on the standard logger
+func (f *FileLogger) SetStartupCheck(enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.startupCheck = enabled
+}
+
+// SetSplitOnError sets whether the log file is rotated right after an ERROR entry is written to it,
+// so the error ends up at the end of a bak file, default is false. Ignored by a fileLogger of SplitType_None,
+// see Rotate
+func (f *FileLogger) SetSplitOnError(enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.splitOnError = enabled
+}
+
+// SetRotationLockFile sets whether split takes the lock file <logFile>.rotating, default is false.
+// Use it when several fileLoggers, e.g. of several processes, write the same log file:
+// the one failing to take the lock skips the split and reopens the log file instead of renaming it twice
+func (f *FileLogger) SetRotationLockFile(enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rotationLock = enabled
+}
+
+// SetDateLinks sets whether each bak file of a size split, e.g. app.log.3, is also hard linked
+// as linkDir/app.log.<date>.3 to find it by date, default is false. An empty linkDir is fileDir.
+// The link keeps the content once the bak file is overwritten by a split of a later date,
+// a split of the same date replaces the link.
+// The bak files of daily loggers are named by date already and are not linked
+func (f *FileLogger) SetDateLinks(enabled bool, linkDir string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if linkDir == "" {
+		linkDir = f.fileDir
+	}
+	f.dateLinks = enabled
+	f.linkDir = linkDir
+}
+
+// SetOwner sets the uid and gid the log files are chowned to each time one is opened or created,
+// e.g. for a service started as root dropping its privileges. The current log file is chowned at once.
+// fileDir must stay writable by the new owner for the log files to be split. Ignored on windows.
+// A uid or gid below -1, or one the current log file can not be chowned to, returns ErrInvalidConfig
+// and keeps the previous owner. -1 leaves the uid or gid unchanged, as with os.Chown
+func (f *FileLogger) SetOwner(uid, gid int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if uid < -1 || gid < -1 {
+		return fmt.Errorf("%w: owner %v:%v", ErrInvalidConfig, uid, gid)
+	}
+
+	if f.logFile != nil && runtime.GOOS != "windows" {
+		if err := os.Chown(f.logFile.Name(), uid, gid); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+	}
+
+	f.owned = true
+	f.uid = uid
+	f.gid = gid
+
+	return nil
+}
+
+// SetCurrentUser sets the owner of the log files to the uid and gid of the process at the time of the call,
+// see SetOwner
+func (f *FileLogger) SetCurrentUser() error {
+	return f.SetOwner(os.Getuid(), os.Getgid())
+}
+
 // Copy from go sdk
 // These flags define which text to prefix to each log entry generated by the Logger.
 const (
@@ -0,0 +1,99 @@
+// Package: fileLogger
+// File: simulate.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: preview the disk usage of a retention policy without writing any file
+// DATE: 26-10-14 10:40
+package fileLogger
+
+import (
+	"time"
+)
+
+// DayStats holds one simulated day
+type DayStats struct {
+	Day            int   // from 1
+	RotationCount  int   // splits of the log file
+	TotalDiskBytes int64 // peak size of the log file and its bak files
+	DeletedFiles   int   // bak files overwritten by a split
+}
+
+// SimulationResult holds the days of a simulation
+type SimulationResult struct {
+	Days         []DayStats
+	MaxDiskBytes int64
+}
+
+// SimulateRotation simulates days of logging entriesPerDay entries of entrySize bytes, spread evenly over the day,
+// to the logger NewLoggerFromConfig(cfg) builds, starting at midnight with no log file. As fileMonitor does,
+// the log file is checked for a split every cfg.LogScan seconds, DEFAULT_LOG_SCAN when it is not set.
+// Bak files of a daily logger are never deleted
+func SimulateRotation(days int, entriesPerDay int, entrySize int, cfg Config) SimulationResult {
+	cfg = cfg.withDefaults()
+
+	fileCount := cfg.FileCount
+	if cfg.SplitType == SplitType_HybridScheduled {
+		fileCount = DEFAULT_FILE_COUNT
+	}
+
+	scan := time.Duration(cfg.LogScan) * time.Second
+	steps := int64(24 * time.Hour / scan)
+	if steps < 1 {
+		steps = 1
+	}
+	perDay := int64(entriesPerDay) * int64(entrySize)
+
+	result := SimulationResult{Days: make([]DayStats, 0, days)}
+	var current, baksSize int64
+	baks := make(map[int]int64)
+	suffix := 0
+
+	for day := 1; day <= days; day++ {
+		stats := DayStats{Day: day}
+
+		// the daily split happens at the first check of the day
+		if cfg.SplitType == SplitType_Daily && day > 1 {
+			baks[day-1] = current
+			baksSize += current
+			current = 0
+			stats.RotationCount++
+		}
+
+		for step := int64(0); step < steps; step++ {
+			current += perDay*(step+1)/steps - perDay*step/steps
+
+			threshold := int64(-1)
+			switch cfg.SplitType {
+			case SplitType_Size:
+				threshold = cfg.FileSize * int64(cfg.Unit)
+			case SplitType_HybridScheduled:
+				threshold = cfg.PeakSize * int64(cfg.Unit)
+				if inPeak(time.Duration(step+1)*scan, cfg.PeakStart, cfg.PeakEnd) {
+					threshold = cfg.OffPeakSize * int64(cfg.Unit)
+				}
+			}
+
+			if disk := current + baksSize; disk > stats.TotalDiskBytes {
+				stats.TotalDiskBytes = disk
+			}
+
+			if threshold >= 0 && fileCount > 1 && current >= threshold {
+				suffix = suffix%fileCount + 1
+				if size, ok := baks[suffix]; ok {
+					baksSize -= size
+					stats.DeletedFiles++
+				}
+				baks[suffix] = current
+				baksSize += current
+				current = 0
+				stats.RotationCount++
+			}
+		}
+
+		result.Days = append(result.Days, stats)
+		if stats.TotalDiskBytes > result.MaxDiskBytes {
+			result.MaxDiskBytes = stats.TotalDiskBytes
+		}
+	}
+
+	return result
+}
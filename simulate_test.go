@@ -0,0 +1,47 @@
+package fileLogger
+
+import (
+	"testing"
+)
+
+func TestSimulateRotation(t *testing.T) {
+	const limit = 100 * int64(MB)
+	cfg := Config{SplitType: SplitType_Size, FileCount: 5, FileSize: 100, Unit: MB}
+
+	result := SimulateRotation(30, 250000, 1000, cfg)
+	if len(result.Days) != 30 {
+		t.Fatalf("%d days simulated, want 30", len(result.Days))
+	}
+
+	// a split happens at the first check past the limit: the log file and each of the
+	// FileCount bak files hold at most the limit and what is written between two checks
+	perScan := 250000 * 1000 * int64(DEFAULT_LOG_SCAN) / 86400
+	if max := int64(cfg.FileCount+1) * (limit + perScan); result.MaxDiskBytes > max {
+		t.Errorf("MaxDiskBytes = %d, want at most %d", result.MaxDiskBytes, max)
+	}
+	for _, day := range result.Days {
+		if day.TotalDiskBytes > result.MaxDiskBytes {
+			t.Errorf("day %d uses %d bytes, above MaxDiskBytes %d", day.Day, day.TotalDiskBytes, result.MaxDiskBytes)
+		}
+		if day.RotationCount < 2 {
+			t.Errorf("day %d has %d rotations, want 2 for 250 MB a day", day.Day, day.RotationCount)
+		}
+		if day.Day > 3 && day.DeletedFiles == 0 {
+			t.Errorf("day %d deletes no bak file, want the oldest overwritten once the 5 are used", day.Day)
+		}
+	}
+}
+
+// the log file is checked every cfg.LogScan seconds: checking less often lets it grow further past the limit
+func TestSimulateRotationLogScan(t *testing.T) {
+	cfg := Config{SplitType: SplitType_Size, FileCount: 5, FileSize: 100, Unit: MB}
+
+	cfg.LogScan = 60
+	often := SimulateRotation(3, 250000, 1000, cfg)
+	cfg.LogScan = 3600
+	seldom := SimulateRotation(3, 250000, 1000, cfg)
+	if often.MaxDiskBytes >= seldom.MaxDiskBytes {
+		t.Errorf("MaxDiskBytes = %d checking every minute and %d every hour, want less when checking more often",
+			often.MaxDiskBytes, seldom.MaxDiskBytes)
+	}
+}
@@ -0,0 +1,86 @@
+// Package: fileLogger
+// File: splitter.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: split an existing mixed-level log file into one file per level
+// DATE: 26-10-14 11:10
+package fileLogger
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SplitByLevel appends each line of srcPath to destDir/<LEVEL>.log, e.g. INFO.log, by the level formatter's
+// Parse reads from it. formatter must implement Parser, a nil formatter reads lines with ParseLine.
+// A line that can not be parsed follows the previous entry, e.g. the rest of a multi-line message,
+// entries of Print() go to OFF.log. Returns the files written by level
+func SplitByLevel(srcPath string, destDir string, formatter Formatter) (map[LEVEL]string, error) {
+	parse := ParseLine
+	if formatter != nil {
+		parser, ok := formatter.(Parser)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T can not parse log lines", ErrInvalidConfig, formatter)
+		}
+		parse = parser.Parse
+	}
+
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, err
+	}
+
+	paths := make(map[LEVEL]string)
+	files := make(map[LEVEL]*os.File)
+	writers := make(map[LEVEL]*bufio.Writer)
+	defer func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}()
+
+	level := OFF
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if e, err := parse(line); err == nil {
+			level = e.Level
+		}
+
+		w, ok := writers[level]
+		if !ok {
+			path := filepath.Join(destDir, level.String()+".log")
+			file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+			if err != nil {
+				return paths, err
+			}
+			paths[level] = path
+			files[level] = file
+			w = bufio.NewWriter(file)
+			writers[level] = w
+		}
+
+		w.WriteString(line)
+		if err := w.WriteByte('\n'); err != nil {
+			return paths, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return paths, err
+	}
+
+	for _, w := range writers {
+		if err := w.Flush(); err != nil {
+			return paths, err
+		}
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,60 @@
+// Package: fileLogger
+// File: stats.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: runtime counters of fileLogger
+// DATE: 26-10-14 06:20
+package fileLogger
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Stats holds a snapshot of the fileLogger's counters
+type Stats struct {
+	// lines written to the log file by logWriter, prefix and line ending included
+	EntriesWritten uint64
+	BytesWritten   uint64
+
+	SlowWriteCount  uint64
+	MaxWriteLatency time.Duration
+
+	// entries dropped by SetQuota and SetLevelQuota
+	QuotaDroppedCount uint64
+
+	// entries dropped by SetLockTimeout
+	LockTimeoutCount uint64
+
+	// entries SetTeeToHTTP failed to POST, dropped while its queue was full or its filter panicked on
+	HTTPTeeErrorCount uint64
+}
+
+// Stats returns the current counters of f
+func (f *FileLogger) Stats() Stats {
+	return Stats{
+		EntriesWritten:    f.entriesWritten.Load(),
+		BytesWritten:      f.bytesWritten.Load(),
+		SlowWriteCount:    f.slowWriteCount.Load(),
+		MaxWriteLatency:   time.Duration(f.maxWriteLatency.Load()),
+		QuotaDroppedCount: f.quotaDroppedCount.Load(),
+		LockTimeoutCount:  f.lockTimeoutCount.Load(),
+		HTTPTeeErrorCount: f.httpTeeErrorCount.Load(),
+	}
+}
+
+// record the latency of a single write to the log file.
+// Slow writes are reported on stderr, writing them to the log file would only make it slower. f.mu is held
+func (f *FileLogger) observeWrite(latency time.Duration) {
+	for {
+		max := f.maxWriteLatency.Load()
+		if int64(latency) <= max || f.maxWriteLatency.CompareAndSwap(max, int64(latency)) {
+			break
+		}
+	}
+
+	if f.slowWriteThreshold > 0 && latency > f.slowWriteThreshold {
+		f.slowWriteCount.Add(1)
+		fmt.Fprintf(os.Stderr, "[fileLogger] slow write: %v for file: %v\n", latency, joinFilePath(f.fileDir, f.fileName))
+	}
+}
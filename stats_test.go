@@ -0,0 +1,55 @@
+package fileLogger
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlowWriteThreshold(t *testing.T) {
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+
+	l.I("fast")
+	l.Flush()
+	if n := l.Stats().SlowWriteCount; n != 0 {
+		t.Fatalf("SlowWriteCount = %v without a threshold", n)
+	}
+
+	stderr := captureStderr(t, func() {
+		l.SetSlowWriteThreshold(time.Nanosecond)
+		l.I("slow")
+		l.Flush()
+	})
+	if n := l.Stats().SlowWriteCount; n != 1 {
+		t.Errorf("SlowWriteCount = %v, want 1", n)
+	}
+	if l.Stats().MaxWriteLatency <= 0 {
+		t.Errorf("MaxWriteLatency = %v, want the latency of the slow write", l.Stats().MaxWriteLatency)
+	}
+	if want := "[fileLogger] slow write: "; !strings.HasPrefix(stderr, want) || !strings.HasSuffix(stderr, " for file: "+filepath.Join(l.fileDir, "a.log")+"\n") {
+		t.Errorf("stderr = %q, want the slow write warning", stderr)
+	}
+}
+
+// return what fn writes to os.Stderr
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	fn()
+	os.Stderr = stderr
+	w.Close()
+
+	b, _ := io.ReadAll(r)
+	r.Close()
+	return string(b)
+}
@@ -0,0 +1,47 @@
+// Package: fileLogger
+// File: stdlog.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: capture the output of the standard log package
+// DATE: 26-10-14 13:10
+package fileLogger
+
+import (
+	"log"
+	"strings"
+)
+
+// stdLogWriter writes each line of the standard logger to fl at level
+type stdLogWriter struct {
+	fl    *FileLogger
+	level LEVEL
+}
+
+// RedirectStdLog sends the output of the standard logger, e.g. log.Println of imported libraries, to fl at level.
+// The standard logger's flags are cleared since fl adds its own timestamp, its prefix is kept.
+// Entries carry the file and line of the code calling the log package.
+// The returned func restores the standard logger's previous output and flags
+func RedirectStdLog(fl *FileLogger, level LEVEL) func() {
+	out, flags := log.Writer(), log.Flags()
+	log.SetOutput(&stdLogWriter{fl: fl, level: level})
+	log.SetFlags(0)
+
+	return func() {
+		log.SetOutput(out)
+		log.SetFlags(flags)
+	}
+}
+
+// Write implements io.Writer, the log package calls it once per line
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	if !w.fl.enabled(w.level) {
+		return len(p), nil
+	}
+
+	frame := callerFrame()
+	msg := strings.TrimSuffix(string(p), "\n")
+	if err := w.fl.send(w.fl.entry(w.level, frame.File, frame.Line, msg, nil)); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,80 @@
+package fileLogger_test
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/aiwuTech/fileLogger"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+// the entry carries the file and line calling log.Println, which has to be outside of package fileLogger
+func TestRedirectStdLog(t *testing.T) {
+	var out bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	defer log.SetFlags(log.Flags())
+	log.SetOutput(&out)
+	log.SetFlags(log.Lshortfile)
+
+	dir := t.TempDir()
+	l := fileLogger.NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+
+	restore := fileLogger.RedirectStdLog(l, fileLogger.WARN)
+	_, _, line, _ := runtime.Caller(0)
+	log.Println("from the standard logger")
+	restore()
+	log.Println("after restoring")
+	l.Flush()
+
+	content := readFile(t, filepath.Join(dir, "a.log"))
+	for _, want := range []string{fmt.Sprintf("[stdlog_test.go:%d]", line+1), "[WARN] from the standard logger"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("log file is %q, want %q in it", content, want)
+		}
+	}
+	if strings.Contains(content, "after restoring") || strings.Count(content, "\n") != 1 {
+		t.Errorf("log file is %q, want only the entry logged before restoring", content)
+	}
+
+	if log.Writer() != &out || log.Flags() != log.Lshortfile {
+		t.Errorf("standard logger writes to %v with flags %v, want its previous output and flags", log.Writer(), log.Flags())
+	}
+	if got := out.String(); !strings.HasPrefix(got, "stdlog_test.go:") || !strings.HasSuffix(got, ": after restoring\n") {
+		t.Errorf("standard logger output is %q, want the entry logged after restoring", got)
+	}
+}
+
+func TestRedirectStdLogLevel(t *testing.T) {
+	defer log.SetOutput(log.Writer())
+	defer log.SetFlags(log.Flags())
+
+	dir := t.TempDir()
+	l := fileLogger.NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.SetLogLevel(fileLogger.WARN)
+
+	defer fileLogger.RedirectStdLog(l, fileLogger.INFO)()
+	log.Println("below the log level")
+	l.Flush()
+
+	if content := readFile(t, filepath.Join(dir, "a.log")); content != "" {
+		t.Errorf("log file is %q, want the entry below the log level dropped", content)
+	}
+}
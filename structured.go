@@ -0,0 +1,128 @@
+// Package: fileLogger
+// File: structured.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: log methods writing structured fields
+// DATE: 26-10-14 09:20
+package fileLogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime"
+	"time"
+)
+
+const (
+	MISSING_VALUE = "MISSING_VALUE"
+)
+
+// WriteKV writes msg at level with keyvals as fields, keyvals alternate keys and values
+// in the manner of log/slog: WriteKV(INFO, "user logged in", "user_id", 42, "ip", "127.0.0.1").
+// A key without value gets MISSING_VALUE, a key which is not a string is formatted with fmt.
+// Values other than strings, numbers, bools and times are formatted during the call, the caller may change them afterwards
+func (f *FileLogger) WriteKV(level LEVEL, msg string, keyvals ...interface{}) error {
+	_, file, line, _ := runtime.Caller(1) //calldepth=2
+	if !f.enabled(level) {
+		return nil
+	}
+
+	e := f.entry(level, file, line, msg, nil)
+	for i := 0; i < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+
+		if i+1 < len(keyvals) {
+			e.setField(key, freeze(keyvals[i+1]))
+		} else {
+			e.setField(key, MISSING_VALUE)
+		}
+	}
+
+	return f.send(e)
+}
+
+// Event is something that happened, as opposed to a trace message
+type Event struct {
+	Name     string
+	Actor    string
+	Resource string
+	Action   string
+	Result   string
+	Metadata map[string]interface{}
+}
+
+// WriteEvent writes ev at level, its message is the event's name. The non-zero fields of ev are written
+// as the fields name, actor, resource, action, result and metadata. An event without name is not written
+// and returns ErrMissingEventName
+func (f *FileLogger) WriteEvent(level LEVEL, ev Event) error {
+	_, file, line, _ := runtime.Caller(1) //calldepth=2
+	if ev.Name == "" {
+		return ErrMissingEventName
+	}
+	if !f.enabled(level) {
+		return nil
+	}
+
+	e := f.entry(level, file, line, ev.Name, nil)
+	for _, field := range []StringField{
+		{"name", ev.Name},
+		{"actor", ev.Actor},
+		{"resource", ev.Resource},
+		{"action", ev.Action},
+		{"result", ev.Result},
+	} {
+		if field.Value != "" {
+			e.setField(field.Key, field.Value)
+		}
+	}
+	if len(ev.Metadata) > 0 {
+		// the caller may reuse the map once logged
+		metadata := make(map[string]interface{}, len(ev.Metadata))
+		for k, v := range ev.Metadata {
+			metadata[k] = freeze(v)
+		}
+		e.setField("metadata", metadata)
+	}
+
+	return f.send(e)
+}
+
+// frozenValue is a field value formatted as text and JSON when it is logged
+type frozenValue struct {
+	text string
+	json []byte
+}
+
+func (v frozenValue) String() string {
+	return v.text
+}
+
+func (v frozenValue) MarshalJSON() ([]byte, error) {
+	return v.json, nil
+}
+
+// return v as it is when it can not change once logged, a frozenValue otherwise:
+// maps, slices, pointers and the structs holding them are only formatted later by logWriter
+func freeze(v interface{}) interface{} {
+	if _, ok := v.(time.Time); ok || v == nil {
+		return v
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return v
+	}
+
+	text := fmt.Sprintf("%v", v)
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(text)
+	}
+
+	return frozenValue{text: text, json: b}
+}
@@ -0,0 +1,132 @@
+package fileLogger
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestWriteKV(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+
+	if err := l.WriteKV(INFO, "user logged in", "user_id", 42, "ip", "127.0.0.1", "session"); err != nil {
+		t.Fatal(err)
+	}
+	l.Flush()
+
+	content := readLog(t, filepath.Join(dir, "a.log"))
+	for _, want := range []string{"user logged in", " ip=127.0.0.1", " user_id=42", " session=" + MISSING_VALUE} {
+		if !strings.Contains(content, want) {
+			t.Errorf("a.log = %q, want it to hold %q", content, want)
+		}
+	}
+}
+
+// read back the only line of the log file of l, written by JSONFormatter with no prefix nor timestamp
+func readJSONLine(t *testing.T, path string) map[string]interface{} {
+	t.Helper()
+
+	obj := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(readLog(t, path)), &obj); err != nil {
+		t.Fatal(err)
+	}
+
+	return obj
+}
+
+func TestWriteKVJSON(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.SetFormatter(&JSONFormatter{})
+	l.SetFlags(0)
+
+	l.WriteKV(INFO, "entry", "user_id", 42, "msg", "field", "time", "t", "file", "f", "line", 1, "tags", []string{"a"})
+	l.Flush()
+
+	obj := readJSONLine(t, filepath.Join(dir, "a.log"))
+	if obj["msg"] != "entry" || obj["file"] != "structured_test.go" || obj["user_id"] != float64(42) {
+		t.Errorf("entry = %v, want msg entry, file structured_test.go and user_id 42", obj)
+	}
+	for key, want := range map[string]interface{}{"msg": "field", "time": "t", "file": "f", "line": float64(1)} {
+		if got := obj[RESERVED_FIELD_PREFIX+key]; got != want {
+			t.Errorf("%v%v = %v, want %v", RESERVED_FIELD_PREFIX, key, got, want)
+		}
+	}
+	if tags, _ := obj["tags"].([]interface{}); len(tags) != 1 || tags[0] != "a" {
+		t.Errorf("tags = %v, want [a]", obj["tags"])
+	}
+}
+
+// a value changed by the caller once WriteKV returned is logged as it was during the call
+func TestWriteKVChangedAfterCall(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+
+	tags := map[string]int{"a": 1}
+	ids := []int{1}
+	for i := 0; i < 100; i++ {
+		l.WriteKV(INFO, "entry", "tags", tags, "ids", ids)
+		tags["a"]++
+		ids[0]++
+	}
+	l.Flush()
+
+	if first := strings.SplitN(readLog(t, filepath.Join(dir, "a.log")), "\n", 2)[0]; !strings.Contains(first, "ids=[1] tags=map[a:1]") {
+		t.Errorf("first line = %q, want the values during the call", first)
+	}
+}
+
+func TestWriteEvent(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.SetFormatter(&JSONFormatter{})
+	l.SetFlags(0)
+
+	if err := l.WriteEvent(INFO, Event{}); !errors.Is(err, ErrMissingEventName) {
+		t.Errorf("WriteEvent() without name = %v, want ErrMissingEventName", err)
+	}
+	if err := l.WriteEvent(INFO, Event{Name: "user_created", Action: "create"}); err != nil {
+		t.Fatal(err)
+	}
+	l.Flush()
+
+	obj := readJSONLine(t, filepath.Join(dir, "a.log"))
+	keys := []string{}
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if got := strings.Join(keys, ","); got != "action,file,level,line,msg,name,time" {
+		t.Errorf("keys = %v, want action and name besides the members of the entry", got)
+	}
+	if obj["name"] != "user_created" || obj["action"] != "create" {
+		t.Errorf("entry = %v, want name user_created and action create", obj)
+	}
+}
+
+// metadata changed by the caller once WriteEvent returned is logged as it was during the call
+func TestWriteEventMetadataChangedAfterCall(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+
+	metadata := map[string]interface{}{"n": 1, "tags": []string{"a"}}
+	for i := 0; i < 100; i++ {
+		l.WriteEvent(INFO, Event{Name: "deploy", Metadata: metadata})
+		metadata["n"] = i + 2
+		metadata["tags"].([]string)[0] = "b"
+	}
+	l.Flush()
+
+	if first := strings.SplitN(readLog(t, filepath.Join(dir, "a.log")), "\n", 2)[0]; !strings.Contains(first, "metadata=map[n:1 tags:[a]]") {
+		t.Errorf("first line = %q, want metadata=map[n:1 tags:[a]]", first)
+	}
+}
@@ -0,0 +1,98 @@
+// Package: fileLogger
+// File: syslog.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: copy log entries to the local syslog daemon
+// DATE: 26-10-14 09:40
+
+//go:build !windows
+
+package fileLogger
+
+import (
+	"log/syslog"
+	"sync"
+)
+
+// syslog connection of a fileLogger, dialed on first use and again after a failed write
+type syslogSink struct {
+	mu       sync.Mutex
+	priority syslog.Priority
+	tag      string
+	w        *syslog.Writer
+	// dials the daemon, syslog.New when nil
+	dial func(priority syslog.Priority, tag string) (*syslog.Writer, error)
+}
+
+// SetSyslog sets the syslog priority (facility and default severity) and tag each log entry is
+// also sent with to the local syslog daemon, e.g. SYSLOG_LOCAL0|SYSLOG_INFO. The severity follows the entry's level:
+// TRACE is LOG_DEBUG, INFO LOG_INFO, WARN LOG_WARNING and ERROR LOG_ERR. SetSyslog is ignored on windows
+func (f *FileLogger) SetSyslog(priority SyslogPriority, tag string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.syslog != nil {
+		f.syslog.close()
+	}
+	f.syslog = &syslogSink{priority: syslog.Priority(priority), tag: tag}
+}
+
+// send e to syslog, called by logWriter
+func (f *FileLogger) writeSyslog(e *Entry) {
+	f.mu.RLock()
+	sink := f.syslog
+	f.mu.RUnlock()
+
+	if sink != nil {
+		sink.write(e.Level, e.text(false))
+	}
+}
+
+// write msg with the severity of level, redialing once when the connection is lost
+func (s *syslogSink) write(level LEVEL, msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for retry := 0; retry < 2; retry++ {
+		if s.w == nil {
+			dial := s.dial
+			if dial == nil {
+				dial = syslog.New
+			}
+			w, err := dial(s.priority, s.tag)
+			if err != nil {
+				return
+			}
+			s.w = w
+		}
+
+		var err error
+		switch level {
+		case TRACE:
+			err = s.w.Debug(msg)
+		case INFO:
+			err = s.w.Info(msg)
+		case WARN:
+			err = s.w.Warning(msg)
+		case ERROR:
+			err = s.w.Err(msg)
+		default:
+			_, err = s.w.Write([]byte(msg))
+		}
+		if err == nil {
+			return
+		}
+
+		s.w.Close()
+		s.w = nil
+	}
+}
+
+func (s *syslogSink) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.w != nil {
+		s.w.Close()
+		s.w = nil
+	}
+}
@@ -0,0 +1,46 @@
+//go:build !windows
+
+package fileLogger
+
+import (
+	"log/syslog"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetSyslog(t *testing.T) {
+	addr := filepath.Join(t.TempDir(), "log")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: addr, Net: "unixgram"})
+	if err != nil {
+		t.Skip(err)
+	}
+	defer conn.Close()
+
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+	l.SetSyslog(SYSLOG_LOCAL0|SYSLOG_INFO, "app")
+	// a local listener stands for the daemon
+	l.syslog.dial = func(priority syslog.Priority, tag string) (*syslog.Writer, error) {
+		return syslog.Dial("unixgram", addr, priority, tag)
+	}
+
+	l.I("started")
+	l.E("failed")
+	l.Flush()
+
+	// LOCAL0 is 16<<3, INFO 6 and ERR 3
+	for _, want := range []string{"<134>", "<131>"} {
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		buf := make([]byte, 1024)
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatalf("no syslog message %v: %v", want, err)
+		}
+		if msg := string(buf[:n]); !strings.HasPrefix(msg, want) || !strings.Contains(msg, "app[") {
+			t.Errorf("syslog message = %q, want priority %v and tag app", msg, want)
+		}
+	}
+}
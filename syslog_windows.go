@@ -0,0 +1,13 @@
+// Package: fileLogger
+// File: syslog_windows.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: there is no syslog on windows, SetSyslog is ignored
+// DATE: 26-10-14 09:40
+package fileLogger
+
+type syslogSink struct{}
+
+// SetSyslog is ignored on windows, entries are only written to the log file
+func (f *FileLogger) SetSyslog(priority SyslogPriority, tag string) {}
+
+func (f *FileLogger) writeSyslog(e *Entry) {}
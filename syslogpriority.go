@@ -0,0 +1,49 @@
+// Package: fileLogger
+// File: syslogpriority.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: syslog priorities of SetSyslog, available on every platform unlike log/syslog
+// DATE: 26-10-14 09:40
+package fileLogger
+
+// SyslogPriority is a syslog facility and severity, with the values of log/syslog.Priority
+type SyslogPriority int
+
+// severities
+const (
+	SYSLOG_EMERG SyslogPriority = iota
+	SYSLOG_ALERT
+	SYSLOG_CRIT
+	SYSLOG_ERR
+	SYSLOG_WARNING
+	SYSLOG_NOTICE
+	SYSLOG_INFO
+	SYSLOG_DEBUG
+)
+
+// facilities
+const (
+	SYSLOG_KERN SyslogPriority = iota << 3
+	SYSLOG_USER
+	SYSLOG_MAIL
+	SYSLOG_DAEMON
+	SYSLOG_AUTH
+	SYSLOG_SYSLOG
+	SYSLOG_LPR
+	SYSLOG_NEWS
+	SYSLOG_UUCP
+	SYSLOG_CRON
+	SYSLOG_AUTHPRIV
+	SYSLOG_FTP
+	_
+	_
+	_
+	_
+	SYSLOG_LOCAL0
+	SYSLOG_LOCAL1
+	SYSLOG_LOCAL2
+	SYSLOG_LOCAL3
+	SYSLOG_LOCAL4
+	SYSLOG_LOCAL5
+	SYSLOG_LOCAL6
+	SYSLOG_LOCAL7
+)
@@ -0,0 +1,128 @@
+// Package: fileLogger
+// File: tee.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: POST written entries to a webhook, e.g. of an alerting system
+// DATE: 26-10-14 12:50
+package fileLogger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	DEFAULT_HTTP_TEE_TIMEOUT = 5 * time.Second
+)
+
+// httpTee POSTs the entries queued by logWriter one at a time, in order
+type httpTee struct {
+	url    string
+	client *http.Client
+	filter func(Entry) bool
+	queue  chan string
+}
+
+// SetTeeToHTTP POSTs each entry written to the log file for which filter returns true to url,
+// as a JSON object formatted by JSONFormatter. A nil client is http.DefaultClient, a nil filter passes every entry.
+// Entries are posted in order by a goroutine of their own, so the log file is never waited for:
+// a failed POST, a status other than 2xx, an entry dropped while DEFAULT_LOG_SEQ entries wait
+// or a panic of filter is counted in Stats().HTTPTeeErrorCount. An empty url stops the tee
+func (f *FileLogger) SetTeeToHTTP(url string, client *http.Client, filter func(Entry) bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.tee != nil {
+		close(f.tee.queue)
+		f.tee = nil
+	}
+	if url == "" {
+		return
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	f.tee = &httpTee{url: url, client: client, filter: filter, queue: make(chan string, DEFAULT_LOG_SEQ)}
+	go f.postEntries(f.tee)
+}
+
+// SetHTTPTeeTimeout sets the timeout of each POST of SetTeeToHTTP, default is DEFAULT_HTTP_TEE_TIMEOUT
+func (f *FileLogger) SetHTTPTeeTimeout(d time.Duration) {
+	f.httpTeeTimeout.Store(int64(d))
+}
+
+// queue e to be posted when it passes the filter, called by logWriter
+func (f *FileLogger) teeToHTTP(e *Entry) {
+	f.mu.RLock()
+	tee := f.tee
+	f.mu.RUnlock()
+
+	if tee == nil {
+		return
+	}
+	pass := tee.filter == nil
+	if !pass && !f.safely("http tee filter", func() { pass = tee.filter(*e) }) {
+		f.httpTeeErrorCount.Add(1)
+		return
+	}
+	if !pass {
+		return
+	}
+	body := (&JSONFormatter{}).Format(e)
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	// the queue of a tee replaced meanwhile is closed
+	if f.tee != tee {
+		return
+	}
+	select {
+	case tee.queue <- body:
+	default:
+		f.httpTeeErrorCount.Add(1)
+	}
+}
+
+// post the entries of tee until its queue is closed
+func (f *FileLogger) postEntries(tee *httpTee) {
+	for body := range tee.queue {
+		if err := f.post(tee, body); err != nil {
+			f.httpTeeErrorCount.Add(1)
+		}
+	}
+}
+
+func (f *FileLogger) post(tee *httpTee, body string) error {
+	timeout := time.Duration(f.httpTeeTimeout.Load())
+	if timeout <= 0 {
+		timeout = DEFAULT_HTTP_TEE_TIMEOUT
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tee.url, bytes.NewBufferString(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := tee.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// drain the body so the connection is reused
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%v: %v", tee.url, resp.Status)
+	}
+
+	return nil
+}
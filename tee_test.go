@@ -0,0 +1,97 @@
+package fileLogger
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTeeToHTTP(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	l := NewPlainLogger(t.TempDir(), "a.log", "")
+	defer l.Close()
+	l.SetTeeToHTTP(srv.URL, nil, func(e Entry) bool {
+		if e.Msg == "boom" {
+			panic("filter failed")
+		}
+		return e.Level >= ERROR
+	})
+
+	l.I("info")
+	l.E("boom")
+	l.E("error")
+	l.Flush()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := len(bodies)
+		mu.Unlock()
+		if n > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 1 || !strings.Contains(bodies[0], `"msg":"error"`) {
+		t.Errorf("posted %q, want only the error entry", bodies)
+	}
+	if n := l.Stats().HTTPTeeErrorCount; n != 1 {
+		t.Errorf("HTTPTeeErrorCount = %v, want 1 for the panicking filter", n)
+	}
+}
+
+// a webhook failing or hanging does not hold back the log file
+func TestTeeToHTTPDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+	l.SetTeeToHTTP(srv.URL, nil, nil)
+	l.SetHTTPTeeTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	for n := 0; n < 10; n++ {
+		l.I("entry %d", n)
+	}
+	l.Flush()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("writing 10 entries took %v with a hanging webhook", elapsed)
+	}
+	if content := readLog(t, filepath.Join(dir, "a.log")); !strings.Contains(content, "entry 9") {
+		t.Errorf("a.log = %q, want every entry", content)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for l.Stats().HTTPTeeErrorCount == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if l.Stats().HTTPTeeErrorCount == 0 {
+		t.Error("HTTPTeeErrorCount = 0, want the timed out POSTs counted")
+	}
+}
@@ -0,0 +1,18 @@
+// Command pkgmain logs to <dir>/a.log from package main and from package sub, for the package annotation tests
+package main
+
+import (
+	"os"
+
+	"github.com/aiwuTech/fileLogger"
+	"github.com/aiwuTech/fileLogger/testdata/sub"
+)
+
+func main() {
+	l := fileLogger.NewPlainLogger(os.Args[1], "a.log", "")
+	l.SetPackageAnnotation(true)
+
+	l.I("from main")
+	sub.Log(l, "from sub")
+	l.Close()
+}
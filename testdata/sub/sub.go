@@ -0,0 +1,11 @@
+// Package sub logs through a fileLogger from a package of its own, for the package annotation tests
+package sub
+
+import (
+	"github.com/aiwuTech/fileLogger"
+)
+
+// Log writes msg at INFO to l
+func Log(l *fileLogger.FileLogger, msg string) {
+	l.I(msg)
+}
@@ -0,0 +1,76 @@
+// Package: fileLogger
+// File: throughput.go
+// Created by: mint(mint.zhao.chiu@gmail.com)_aiwuTech
+// Useage: report the rate entries are written at, e.g. to tune the log level
+// DATE: 26-10-14 12:20
+package fileLogger
+
+import (
+	"time"
+)
+
+const (
+	THROUGHPUT_SAMPLES = 3
+)
+
+// SetThroughputReport calls fn every interval with the entries and bytes written per second,
+// averaged over the last THROUGHPUT_SAMPLES intervals, as counted by Stats().EntriesWritten and BytesWritten.
+// fn runs on a goroutine of its own, a nil fn or an interval <= 0 stops the report
+func (f *FileLogger) SetThroughputReport(interval time.Duration, fn func(entriesPerSec float64, bytesPerSec float64)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.throughputReport != nil {
+		close(f.throughputReport)
+		f.throughputReport = nil
+	}
+	if fn == nil || interval <= 0 {
+		return
+	}
+
+	// the first sample is taken here, the entries written before the goroutine starts count in the first report
+	f.throughputReport = make(chan struct{})
+	go f.reportThroughput(interval, fn, f.throughputReport, f.throughputSample(time.Now()))
+}
+
+// counters of Stats at a time
+type throughputSample struct {
+	at      time.Time
+	entries uint64
+	bytes   uint64
+}
+
+func (f *FileLogger) throughputSample(at time.Time) throughputSample {
+	return throughputSample{at, f.entriesWritten.Load(), f.bytesWritten.Load()}
+}
+
+// sample the counters every interval from start until stop is closed or f is
+func (f *FileLogger) reportThroughput(interval time.Duration, fn func(float64, float64), stop chan struct{}, start throughputSample) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	samples := []throughputSample{start}
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			f.mu.RLock()
+			closed := f.closed
+			f.mu.RUnlock()
+			if closed {
+				return
+			}
+
+			samples = append(samples, f.throughputSample(now))
+			if len(samples) > THROUGHPUT_SAMPLES+1 {
+				samples = samples[1:]
+			}
+
+			first, last := samples[0], samples[len(samples)-1]
+			seconds := last.at.Sub(first.at).Seconds()
+			fn(float64(last.entries-first.entries)/seconds, float64(last.bytes-first.bytes)/seconds)
+		}
+	}
+}
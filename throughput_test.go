@@ -0,0 +1,83 @@
+package fileLogger
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWrittenCounters(t *testing.T) {
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+
+	for n := 0; n < 50; n++ {
+		l.I("entry %02d", n)
+	}
+	l.Flush()
+
+	stats := l.Stats()
+	if stats.EntriesWritten != 50 {
+		t.Errorf("EntriesWritten is %d, want 50", stats.EntriesWritten)
+	}
+	if size := fileSize(filepath.Join(dir, "a.log")); stats.BytesWritten != uint64(size) {
+		t.Errorf("BytesWritten is %d, want the %d bytes of the log file", stats.BytesWritten, size)
+	}
+
+	l.SetLogLevel(WARN)
+	l.I("filtered")
+	l.Flush()
+	if entries := l.Stats().EntriesWritten; entries != 50 {
+		t.Errorf("EntriesWritten is %d once an entry is filtered, want still 50", entries)
+	}
+}
+
+func TestThroughputReport(t *testing.T) {
+	const interval = 20 * time.Millisecond
+
+	dir := t.TempDir()
+	l := NewPlainLogger(dir, "a.log", "")
+	defer l.Close()
+
+	type report struct{ entries, bytes float64 }
+	reports := make(chan report, 100)
+	l.SetThroughputReport(interval, func(entries, bytes float64) {
+		select {
+		case reports <- report{entries, bytes}:
+		default:
+		}
+	})
+
+	for n := 0; n < 100; n++ {
+		l.I("entry %02d", n)
+	}
+	l.Flush()
+	lineSize := float64(fileSize(filepath.Join(dir, "a.log"))) / 100
+
+	// every line has the same size, so bytes over entries per second is the size of a line
+	var got bool
+	for timeout := time.After(time.Second); !got; {
+		select {
+		case r := <-reports:
+			if r.entries == 0 {
+				continue
+			}
+			got = true
+			if ratio := r.bytes / r.entries; ratio < lineSize-0.01 || ratio > lineSize+0.01 {
+				t.Errorf("%v bytes for %v entries per second, want %v bytes an entry", r.bytes, r.entries, lineSize)
+			}
+		case <-timeout:
+			t.Fatal("no report of the entries written")
+		}
+	}
+
+	l.SetThroughputReport(0, nil)
+	time.Sleep(2 * interval)
+	for len(reports) > 0 {
+		<-reports
+	}
+	time.Sleep(3 * interval)
+	if len(reports) != 0 {
+		t.Errorf("%d reports once stopped", len(reports))
+	}
+}
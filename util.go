@@ -6,8 +6,19 @@
 package fileLogger
 
 import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	hostnameOnce  sync.Once
+	hostnameCache string
 )
 
 // Determine a file or a path exists in the os
@@ -31,7 +42,77 @@ func fileSize(file string) int64 {
 	return f.Size()
 }
 
+// return the os hostname, looked up only once
+func hostname() string {
+	hostnameOnce.Do(func() {
+		hostnameCache, _ = os.Hostname()
+	})
+
+	return hostnameCache
+}
+
+// checkWritable makes sure dir accepts new files by writing and removing a temp file,
+// and that file, when it exists, can be opened for writing
+func checkWritable(dir, file string) error {
+	if !isExist(dir) {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("%w: %w", ErrDirectoryNotWritable, err)
+		}
+	}
+
+	tmp, err := ioutil.TempFile(dir, ".fileLogger-check-")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDirectoryNotWritable, err)
+	}
+	_, err = tmp.WriteString("check")
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+	if err != nil || fileSize(tmp.Name()) != int64(len("check")) {
+		return fmt.Errorf("%w: write to %v failed", ErrDirectoryNotWritable, tmp.Name())
+	}
+
+	if isExist(file) {
+		logFile, err := os.OpenFile(file, os.O_WRONLY, 0666)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrDirectoryNotWritable, err)
+		}
+		logFile.Close()
+	}
+
+	return nil
+}
+
 // return file name without dir
 func shortFileName(file string) string {
 	return filepath.Base(file)
 }
+
+// gzipFile closes both the gzip reader and the file it reads
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipFile) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
+
+// openLog opens a log file for reading, decompressing a path ending in .gz
+func openLog(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return file, nil
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &gzipFile{Reader: gz, file: file}, nil
+}
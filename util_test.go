@@ -0,0 +1,86 @@
+package fileLogger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStartupCheck(t *testing.T) {
+	readOnly := func(t *testing.T, path string, mode os.FileMode) {
+		if os.Geteuid() == 0 {
+			t.Skip("root writes to read-only files")
+		}
+		os.Chmod(path, mode)
+		t.Cleanup(func() { os.Chmod(path, 0755) })
+	}
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T) string
+	}{
+		{"read-only directory", func(t *testing.T) string {
+			dir := t.TempDir()
+			readOnly(t, dir, 0555)
+			return dir
+		}},
+		{"read-only log file", func(t *testing.T) string {
+			dir := t.TempDir()
+			os.WriteFile(filepath.Join(dir, "a.log"), nil, 0444)
+			readOnly(t, filepath.Join(dir, "a.log"), 0444)
+			return dir
+		}},
+		{"file in the way", func(t *testing.T) string {
+			file := filepath.Join(t.TempDir(), "file")
+			os.WriteFile(file, nil, 0666)
+			return filepath.Join(file, "logs")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := tt.setup(t)
+
+			l := newPlainLogger(dir, "a.log", "")
+			if err := l.initLogger(); !errors.Is(err, ErrDirectoryNotWritable) {
+				t.Errorf("initLogger() = %v, want ErrDirectoryNotWritable", err)
+			}
+			l.Close()
+
+			if _, err := NewLoggerFromConfig(Config{SplitType: SplitType_None, FileDir: dir, FileName: "a.log"}); !errors.Is(err, ErrDirectoryNotWritable) {
+				t.Errorf("NewLoggerFromConfig() = %v, want ErrDirectoryNotWritable", err)
+			}
+		})
+	}
+}
+
+func TestStartupCheckDisabled(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(file, nil, 0666)
+
+	l := newPlainLogger(filepath.Join(file, "logs"), "a.log", "")
+	l.SetStartupCheck(false)
+	if err := l.initLogger(); err != nil {
+		t.Errorf("initLogger() = %v, want nil without the startup check", err)
+	}
+	l.Close()
+}
+
+// the log file is checked again each time it is reopened, the setter may run meanwhile
+func TestSetStartupCheckWhileReopening(t *testing.T) {
+	l := NewSizeLogger(t.TempDir(), "a.log", "", 3, 1, MB, DEFAULT_LOG_SCAN, DEFAULT_LOG_SEQ)
+	defer l.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for n := 0; n < 20; n++ {
+			l.SetHostnameSubdir(n%2 == 0)
+		}
+	}()
+	for n := 0; n < 20; n++ {
+		l.SetStartupCheck(n%2 == 0)
+	}
+	<-done
+}
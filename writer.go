@@ -8,7 +8,9 @@ package fileLogger
 import (
 	"fmt"
 	"log"
+	"os"
 	"runtime"
+	"strings"
 	"time"
 )
 
@@ -16,8 +18,13 @@ const (
 	DEFAULT_PRINT_INTERVAL = 300
 )
 
+// console echoes the lines of loggers set to SetLogConsole. It writes where the standard logger writes
+// by default rather than through it, so a standard logger redirected by RedirectStdLog does not loop back
+var console = log.New(os.Stderr, "", log.LstdFlags)
+
 // Receive logStr from f's logChan and print logstr to file
 func (f *FileLogger) logWriter() {
+	defer close(f.stopped)
 	defer func() {
 		if err := recover(); err != nil {
 			log.Printf("FileLogger's LogWritter() catch panic: %v\n", err)
@@ -28,64 +35,274 @@ func (f *FileLogger) logWriter() {
 	printInterval := DEFAULT_PRINT_INTERVAL
 
 	seqTimer := time.NewTicker(time.Duration(printInterval) * time.Second)
+	defer seqTimer.Stop()
 	for {
 		select {
-		case str := <-f.logChan:
-
-			f.p(str)
+		case <-f.done:
+			// write what was queued before Close, Close waits for it
+			for {
+				select {
+				case e := <-f.logChan:
+					f.write(e)
+				default:
+					return
+				}
+			}
+		case e := <-f.logChan:
+			f.write(e)
 		case <-seqTimer.C:
-			f.p(fmt.Sprintf("================ LOG SEQ SIZE:%v ==================", len(f.logChan)))
+			f.p(OFF, fmt.Sprintf("================ LOG SEQ SIZE:%v ==================", len(f.logChan)))
+		}
+	}
+}
+
+// write e taken from logChan, or release the Flush waiting on it
+func (f *FileLogger) write(e *Entry) {
+	if e.flushed != nil {
+		close(e.flushed)
+		freeEntry(e)
+		return
+	}
+
+	// the formatter is set by the user, an entry it panics on is dropped
+	str := ""
+	if f.transform(e) && f.levelAllowed(e) && f.route(e) &&
+		f.safely("formatter", func() { str = f.header(e) + f.format(e) }) && f.p(e.Level, str) {
+		f.writeSyslog(e)
+		f.remember(e)
+		f.teeToHTTP(e)
+		f.bus.publish(e)
+		if e.Level == ERROR {
+			f.splitAfterError()
 		}
 	}
+	freeEntry(e)
 }
 
 // print log
-func (f *FileLogger) p(str string) {
-	f.mu.RLock()
+// The line is built into f.buf by appendLine and written with a single Write. log.Logger.Output benchmarks
+// a little faster, see PERFORMANCE.md, but it formats the time in the local zone or UTC only, ends lines with \n
+// only and does not give back the bytes written, which SetTimezone, SetLineEnding, the quota and the offset index need.
+// Returns false when the line is dropped by the quota of its level or the lock timeout
+func (f *FileLogger) p(level LEVEL, str string) bool {
+	if !f.rlock() {
+		return false
+	}
 	defer f.mu.RUnlock()
 
-	f.lg.Output(2, str)
+	f.buf = f.appendLine(f.buf[:0], str)
+	if !f.chargeQuota(level, int64(len(f.buf))) {
+		return false
+	}
+	if f.offsetIndex {
+		f.indexLine()
+	}
+	start := time.Now()
+	f.logFile.Write(f.buf)
+	f.observeWrite(time.Since(start))
+	f.entriesWritten.Add(1)
+	f.bytesWritten.Add(uint64(len(f.buf)))
 	f.pc(str)
+
+	return true
+}
+
+// rotate the log file the ERROR entry just written to when SetSplitOnError is on
+func (f *FileLogger) splitAfterError() {
+	f.mu.RLock()
+	enabled := f.splitOnError
+	f.mu.RUnlock()
+
+	if !enabled || f.splitType == SplitType_None {
+		return
+	}
+	if (f.splitType == SplitType_Size || f.splitType == SplitType_HybridScheduled) && f.fileCount < 1 {
+		return
+	}
+	if err := f.Rotate(); err != nil {
+		f.mu.RLock()
+		f.printf("FileLogger split on error: %v", err)
+		f.mu.RUnlock()
+	}
+}
+
+// printf writes a message of fileLogger itself to the log file, bypassing logChan
+func (f *FileLogger) printf(format string, v ...interface{}) {
+	if f.logFile != nil {
+		f.logFile.Write(f.appendLine(nil, fmt.Sprintf(format, v...)))
+	}
+}
+
+// appendLine appends str to buf as a log line in the manner of log.Logger: prefix, date & time as set by
+// the flags, then str and the line ending, a trailing newline of str is not repeated.
+// Llongfile and Lshortfile are ignored, entries carry their own [file:line]
+func (f *FileLogger) appendLine(buf []byte, str string) []byte {
+	if f.flag&log.Lmsgprefix == 0 {
+		buf = append(buf, f.prefix...)
+	}
+
+	t := f.now()
+	if f.loc.Load() == nil && f.flag&log.LUTC != 0 {
+		t = t.UTC()
+	}
+	if f.flag&log.Ldate != 0 {
+		buf = t.AppendFormat(buf, "2006/01/02 ")
+	}
+	if f.flag&(log.Ltime|log.Lmicroseconds) != 0 {
+		if f.flag&log.Lmicroseconds != 0 {
+			buf = t.AppendFormat(buf, "15:04:05.000000 ")
+		} else {
+			buf = t.AppendFormat(buf, "15:04:05 ")
+		}
+	}
+
+	if f.flag&log.Lmsgprefix != 0 {
+		buf = append(buf, f.prefix...)
+	}
+	if f.lineEnding == "" || f.lineEnding == "\n" {
+		buf = append(buf, str...)
+		if len(str) == 0 || str[len(str)-1] != '\n' {
+			buf = append(buf, '\n')
+		}
+	} else {
+		buf = append(buf, strings.TrimSuffix(str, "\n")...)
+		buf = append(buf, f.lineEnding...)
+	}
+
+	return buf
 }
 
 // print log in console, default log string wont be print in console
 // NOTICE: when console is on, the process will really slowly
 func (f *FileLogger) pc(str string) {
 	if f.logConsole {
-		if log.Prefix() != f.prefix {
-			log.SetPrefix(f.prefix)
+		if console.Prefix() != f.prefix {
+			console.SetPrefix(f.prefix)
 		}
-		log.Println(str)
+		console.Println(str)
+	}
+}
+
+// build the entry of a log call, v holds the call's arguments
+func (f *FileLogger) entry(level LEVEL, file string, line int, msg string, v []interface{}) *Entry {
+	e := newEntry(level, file, line, msg)
+	e.Time = f.now()
+
+	if f.errorTransformer.Load() {
+		e.addErrorFields(v)
 	}
+	if f.packageAnnotation.Load() {
+		e.setField("pkg", callerPackage())
+	}
+
+	return e
+}
+
+// import path of this package, skipped by callerPackage
+var selfPackage = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	return funcPackage(runtime.FuncForPC(pc).Name())
+}()
+
+// return the import path of the first caller on the stack outside of this package,
+// and outside of the standard log package for the lines of RedirectStdLog
+func callerPackage() string {
+	return funcPackage(callerFrame().Function)
+}
+
+// return the first frame on the stack outside of this package and the standard log package
+func callerFrame() runtime.Frame {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if pkg := funcPackage(frame.Function); pkg != selfPackage && pkg != "log" {
+			return frame
+		}
+		if !more {
+			return runtime.Frame{}
+		}
+	}
+}
+
+// return the package path of a function name such as github.com/a/b.(*T).M or main.main
+func funcPackage(name string) string {
+	dir := ""
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		dir, name = name[:i+1], name[i+1:]
+	}
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[:i]
+	}
+
+	return dir + name
+}
+
+// sprint formats v as fmt.Sprint does. A single string, error or fmt.Stringer skips fmt's reflection,
+// taking fmt's order: a fmt.Formatter is left to fmt, then Error() is preferred to String()
+func sprint(v []interface{}) string {
+	if len(v) == 1 {
+		switch arg := v[0].(type) {
+		case string:
+			return arg
+		case fmt.Formatter:
+		case error:
+			return callString(arg, arg.Error)
+		case fmt.Stringer:
+			return callString(arg, arg.String)
+		}
+	}
+
+	return fmt.Sprint(v...)
+}
+
+// sprintln formats v in the manner of fmt.Sprintln, see sprint
+func sprintln(v []interface{}) string {
+	if len(v) == 1 {
+		return sprint(v) + "\n"
+	}
+
+	return fmt.Sprintln(v...)
+}
+
+// call method of arg, falling back to fmt when it panics, e.g. on a nil pointer receiver
+func callString(arg interface{}, method func() string) (str string) {
+	defer func() {
+		if err := recover(); err != nil {
+			str = fmt.Sprint(arg)
+		}
+	}()
+
+	return method()
 }
 
 // Printf throw logstr to channel to print to the logger.
 // Arguments are handled in the manner of fmt.Printf.
 func (f *FileLogger) Printf(format string, v ...interface{}) {
 	_, file, line, _ := runtime.Caller(1) //calldepth=2
-	f.logChan <- fmt.Sprintf("[%v:%v]", shortFileName(file), line) + fmt.Sprintf(format, v...)
+	f.queue(f.entry(OFF, file, line, fmt.Sprintf(format, v...), v))
 }
 
 // Print throw logstr to channel to print to the logger.
 // Arguments are handled in the manner of fmt.Print.
 func (f *FileLogger) Print(v ...interface{}) {
 	_, file, line, _ := runtime.Caller(1) //calldepth=2
-	f.logChan <- fmt.Sprintf("[%v:%v]", shortFileName(file), line) + fmt.Sprint(v...)
+	f.queue(f.entry(OFF, file, line, sprint(v), v))
 }
 
 // Println throw logstr to channel to print to the logger.
 // Arguments are handled in the manner of fmt.Println.
 func (f *FileLogger) Println(v ...interface{}) {
 	_, file, line, _ := runtime.Caller(1) //calldepth=2
-	f.logChan <- fmt.Sprintf("[%v:%v]", shortFileName(file), line) + fmt.Sprintln(v...)
+	f.queue(f.entry(OFF, file, line, sprintln(v), v))
 }
 
 //======================================================================================================================
 // Trace log
 func (f *FileLogger) Trace(format string, v ...interface{}) {
 	_, file, line, _ := runtime.Caller(2) //calldepth=3
-	if f.logLevel <= TRACE {
-		f.logChan <- fmt.Sprintf("[%v:%v]", shortFileName(file), line) + fmt.Sprintf("\033[32m[TRACE] "+format+" \033[0m ", v...)
+	if f.enabled(TRACE) {
+		f.queue(f.entry(TRACE, file, line, fmt.Sprintf(format, v...), v))
 	}
 }
 
@@ -97,8 +314,8 @@ func (f *FileLogger) T(format string, v ...interface{}) {
 // info log
 func (f *FileLogger) Info(format string, v ...interface{}) {
 	_, file, line, _ := runtime.Caller(2) //calldepth=3
-	if f.logLevel <= INFO {
-		f.logChan <- fmt.Sprintf("[%v:%v]", shortFileName(file), line) + fmt.Sprintf("\033[1;35m[INFO] "+format+" \033[0m ", v...)
+	if f.enabled(INFO) {
+		f.queue(f.entry(INFO, file, line, fmt.Sprintf(format, v...), v))
 	}
 }
 
@@ -110,8 +327,8 @@ func (f *FileLogger) I(format string, v ...interface{}) {
 // warning log
 func (f *FileLogger) Warn(format string, v ...interface{}) {
 	_, file, line, _ := runtime.Caller(2) //calldepth=3
-	if f.logLevel <= WARN {
-		f.logChan <- fmt.Sprintf("[%v:%v]", shortFileName(file), line) + fmt.Sprintf("\033[1;33m[WARN] "+format+" \033[0m ", v...)
+	if f.enabled(WARN) {
+		f.queue(f.entry(WARN, file, line, fmt.Sprintf(format, v...), v))
 	}
 }
 
@@ -123,8 +340,8 @@ func (f *FileLogger) W(format string, v ...interface{}) {
 // error log
 func (f *FileLogger) Error(format string, v ...interface{}) {
 	_, file, line, _ := runtime.Caller(2) //calldepth=3
-	if f.logLevel <= ERROR {
-		f.logChan <- fmt.Sprintf("[%v:%v]", shortFileName(file), line) + fmt.Sprintf("\033[1;4;31m[ERROR] "+format+" \033[0m ", v...)
+	if f.enabled(ERROR) {
+		f.queue(f.entry(ERROR, file, line, fmt.Sprintf(format, v...), v))
 	}
 }
 
@@ -132,3 +349,64 @@ func (f *FileLogger) Error(format string, v ...interface{}) {
 func (f *FileLogger) E(format string, v ...interface{}) {
 	f.Error(format, v...)
 }
+
+// WriteString writes s at level as it is, without the formatting of the log methods
+func (f *FileLogger) WriteString(level LEVEL, s string) error {
+	_, file, line, _ := runtime.Caller(1) //calldepth=2
+	if !f.enabled(level) {
+		return nil
+	}
+
+	return f.send(f.entry(level, file, line, s, nil))
+}
+
+// send e to logChan for the methods returning an error, e goes back to the pool when it is not sent
+func (f *FileLogger) send(e *Entry) error {
+	if !f.rlock() {
+		freeEntry(e)
+		return ErrLockTimeout
+	}
+	closed := f.closed
+	all, lvl := f.quota, f.levelQuotas[e.Level]
+	f.mu.RUnlock()
+
+	if closed {
+		freeEntry(e)
+		return ErrClosed
+	}
+	if all.full() || lvl.full() {
+		n := int64(len(f.format(e)))
+		all.drop(n)
+		lvl.drop(n)
+		f.quotaDroppedCount.Add(1)
+		freeEntry(e)
+		return ErrQuotaExceeded
+	}
+
+	return f.queue(e)
+}
+
+// queue e for logWriter, e goes back to the pool when f is closed or logWriter has stopped.
+// logChan is never closed, a Close while queueing makes the send give up instead of panicking
+func (f *FileLogger) queue(e *Entry) error {
+	select {
+	case <-f.done:
+		freeEntry(e)
+		return ErrClosed
+	case <-f.stopped:
+		freeEntry(e)
+		return ErrWriterStopped
+	default:
+	}
+
+	select {
+	case f.logChan <- e:
+		return nil
+	case <-f.done:
+		freeEntry(e)
+		return ErrClosed
+	case <-f.stopped:
+		freeEntry(e)
+		return ErrWriterStopped
+	}
+}
@@ -0,0 +1,42 @@
+package fileLogger
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type formatterStringer struct{}
+
+func (formatterStringer) Format(s fmt.State, verb rune) { fmt.Fprint(s, "as-formatter") }
+func (formatterStringer) String() string                { return "as-stringer" }
+
+type errorStringer struct{}
+
+func (errorStringer) Error() string  { return "as-error" }
+func (errorStringer) String() string { return "as-stringer" }
+
+type nilStringer struct{ s string }
+
+func (n *nilStringer) String() string { return n.s }
+
+func TestSprint(t *testing.T) {
+	tests := []interface{}{
+		"plain",
+		42,
+		formatterStringer{},
+		errorStringer{},
+		errors.New("boom"),
+		(*nilStringer)(nil),
+	}
+
+	for _, arg := range tests {
+		v := []interface{}{arg}
+		if got, want := sprint(v), fmt.Sprint(v...); got != want {
+			t.Errorf("sprint(%T) = %q, want %q as fmt.Sprint", arg, got, want)
+		}
+		if got, want := sprintln(v), fmt.Sprintln(v...); got != want {
+			t.Errorf("sprintln(%T) = %q, want %q as fmt.Sprintln", arg, got, want)
+		}
+	}
+}